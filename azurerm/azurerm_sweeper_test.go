@@ -39,7 +39,7 @@ func buildConfigForSweepers() (*ArmClient, error) {
 		SkipProviderRegistration: false,
 	}
 
-	return getArmClient(config)
+	return getArmClient(config, providerOptions{})
 }
 
 func shouldSweepAcceptanceTestResource(name string, resourceLocation string, region string) bool {
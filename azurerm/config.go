@@ -1,12 +1,19 @@
 package azurerm
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -53,6 +60,20 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+// providerOptions contains the configuration of the Provider which changes how it behaves, rather than how it
+// authenticates (which is held in the authentication.Config).
+type providerOptions struct {
+	schedulerAPIVersion   string
+	pollingInterval       time.Duration
+	maxConcurrentMySQLOps int
+	requiresImport        bool
+	ignoreSystemTags      bool
+	ignoreForbiddenReads  bool
+	requiredTags          []string
+	caBundlePath          string
+	requestTimeout        time.Duration
+}
+
 // ArmClient contains the handles to all the specific Azure Resource Manager
 // resource classes' respective clients.
 type ArmClient struct {
@@ -62,6 +83,22 @@ type ArmClient struct {
 	usingServicePrincipal    bool
 	environment              azure.Environment
 	skipProviderRegistration bool
+	schedulerAPIVersion      string
+	pollingInterval          time.Duration
+	requiresImport           bool
+	ignoreSystemTags         bool
+	ignoreForbiddenReads     bool
+	requiredTags             []string
+
+	// availableLocations are the locations available to the Subscription, used to validate the `location` of resources
+	availableLocations azureLocations
+
+	// sender is shared by all of the clients, so that the proxy and CA Bundle apply to every request
+	sender autorest.Sender
+
+	// the Resource Manager endpoint and credentials, used to build clients for other subscriptions
+	resourceManagerEndpoint   string
+	resourceManagerAuthorizer autorest.Authorizer
 
 	StopContext context.Context
 
@@ -121,10 +158,14 @@ type ArmClient struct {
 	iothubResourceClient devices.IotHubResourceClient
 
 	// Databases
+	mysqlCheckNameAvailabilityClient     mysql.CheckNameAvailabilityClient
 	mysqlConfigurationsClient            mysql.ConfigurationsClient
 	mysqlDatabasesClient                 mysql.DatabasesClient
 	mysqlFirewallRulesClient             mysql.FirewallRulesClient
+	mysqlLogFilesClient                  mysql.LogFilesClient
 	mysqlServersClient                   mysql.ServersClient
+	mysqlPerformanceTiersClient          mysql.LocationBasedPerformanceTierClient
+	mysqlOperationsLimiter               *operationLimiter
 	postgresqlConfigurationsClient       postgresql.ConfigurationsClient
 	postgresqlDatabasesClient            postgresql.DatabasesClient
 	postgresqlFirewallRulesClient        postgresql.FirewallRulesClient
@@ -141,7 +182,8 @@ type ArmClient struct {
 	keyVaultManagementClient keyVault.BaseClient
 
 	// Monitor
-	monitorAlertRulesClient insights.AlertRulesClient
+	monitorAlertRulesClient         insights.AlertRulesClient
+	monitorDiagnosticSettingsClient insights.DiagnosticSettingsClient
 
 	// Networking
 	applicationGatewayClient        network.ApplicationGatewaysClient
@@ -185,6 +227,8 @@ type ArmClient struct {
 
 	//Scheduler
 	schedulerJobCollectionsClient scheduler.JobCollectionsClient
+	schedulerJobCollectionsCache  *resourceCache
+	schedulerJobsClient           scheduler.JobsClient
 
 	// Storage
 	storageServiceClient storage.AccountsClient
@@ -207,11 +251,151 @@ type ArmClient struct {
 func (c *ArmClient) configureClient(client *autorest.Client, auth autorest.Authorizer) {
 	setUserAgent(client)
 	client.Authorizer = auth
-	client.Sender = autorest.CreateSender(withRequestLogging())
+	client.Sender = c.sender
 	client.SkipResourceProviderRegistration = c.skipProviderRegistration
 	client.PollingDuration = 60 * time.Minute
 }
 
+// configurePollingInterval overrides how frequently Long Running Operations are polled, when the
+// `polling_interval` is specified - otherwise the SDK's default is used. A `Retry-After` header
+// returned from the API takes precedence over this.
+func (c *ArmClient) configurePollingInterval(client *autorest.Client) {
+	if c.pollingInterval <= 0 {
+		return
+	}
+
+	client.PollingDelay = c.pollingInterval
+}
+
+// requestTimeoutRetryAttempts is the number of times a request which times out is sent before giving up
+const requestTimeoutRetryAttempts = 3
+
+// buildSender returns the Sender used by all of the clients. Requests are sent via the proxy specified in the
+// environment (e.g. `HTTPS_PROXY`) and, when a CA Bundle is specified, its certificates are trusted in addition
+// to the system's certificates - for example when the proxy re-signs requests using a custom CA.
+//
+// When a `requestTimeout` is specified each individual request (rather than the overall operation) fails once
+// it's exceeded - and GET/HEAD requests are retried, so that a stalled connection doesn't consume the whole
+// operation's timeout. Other requests (e.g. a PUT or DELETE) may have been applied, so aren't retried.
+func buildSender(caBundlePath string, requestTimeout time.Duration) (autorest.Sender, error) {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	if caBundlePath != "" {
+		log.Printf("[DEBUG] Trusting the certificates in the CA Bundle %q", caBundlePath)
+		pool, err := loadCABundle(caBundlePath)
+		if err != nil {
+			return nil, err
+		}
+
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs: pool,
+		}
+	}
+
+	httpClient := &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+	}
+
+	return autorest.DecorateSender(httpClient, withRequestLogging(), withRetryOnRequestTimeout(requestTimeoutRetryAttempts), withBufferedErrorResponses()), nil
+}
+
+// withBufferedErrorResponses buffers the body of error responses, so that the error returned from Azure can be read
+// even once the SDK has consumed the body - which is the case for a 409 Conflict, which the SDK checks for a missing
+// Resource Provider registration and then returns as a plain error, without the ServiceError
+func withBufferedErrorResponses() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			resp, err := s.Do(r)
+			if err != nil || resp == nil || resp.Body == nil || resp.StatusCode < http.StatusBadRequest {
+				return resp, err
+			}
+
+			b, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return resp, err
+			}
+
+			resp.Body = &armErrorResponseBody{
+				Reader: bytes.NewReader(b),
+				raw:    b,
+			}
+			return resp, nil
+		})
+	}
+}
+
+// withRetryOnRequestTimeout sends a GET or HEAD request again when it times out, up to the number of `attempts` -
+// other requests aren't idempotent, so are returned as-is
+func withRetryOnRequestTimeout(attempts int) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (resp *http.Response, err error) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				return s.Do(r)
+			}
+
+			rr := autorest.NewRetriableRequest(r)
+			for attempt := 1; attempt <= attempts; attempt++ {
+				if err = rr.Prepare(); err != nil {
+					return resp, err
+				}
+
+				resp, err = s.Do(rr.Request())
+				if !isRequestTimeout(err) {
+					return resp, err
+				}
+
+				log.Printf("[DEBUG] Request to %s timed out (attempt %d of %d): %+v", r.URL, attempt, attempts, err)
+			}
+
+			return resp, err
+		})
+	}
+}
+
+func isRequestTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
+
+// loadCABundle returns the system's certificate pool with the PEM encoded certificates from the CA Bundle appended
+func loadCABundle(path string) (*x509.CertPool, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading the CA Bundle %q: %+v", path, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(contents) {
+		return nil, fmt.Errorf("Error parsing the CA Bundle %q: no PEM encoded certificates were found", path)
+	}
+
+	return pool, nil
+}
+
 func withRequestLogging() autorest.SendDecorator {
 	return func(s autorest.Sender) autorest.Sender {
 		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
@@ -240,6 +424,49 @@ func withRequestLogging() autorest.SendDecorator {
 	}
 }
 
+// withAPIVersion overrides the `api-version` query string parameter which
+// the SDK sets on each request, allowing a different API version to be used.
+func withAPIVersion(apiVersion string) autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil || apiVersion == "" || r.URL == nil {
+				return r, err
+			}
+
+			query := r.URL.Query()
+			if query.Get("api-version") != "" {
+				query.Set("api-version", apiVersion)
+				r.URL.RawQuery = query.Encode()
+			}
+
+			return r, nil
+		})
+	}
+}
+
+// withRequestIDLogging logs the `x-ms-request-id` header returned from the API, which allows
+// the request to be correlated with Azure's logs when raising a support request.
+func withRequestIDLogging() autorest.RespondDecorator {
+	return func(r autorest.Responder) autorest.Responder {
+		return autorest.ResponderFunc(func(resp *http.Response) error {
+			if resp != nil {
+				if requestId := resp.Header.Get("x-ms-request-id"); requestId != "" {
+					method, url := "", ""
+					if resp.Request != nil {
+						method = resp.Request.Method
+						url = resp.Request.URL.String()
+					}
+
+					log.Printf("[DEBUG] AzureRM Request ID %q: %s %s returned %d", requestId, method, url, resp.StatusCode)
+				}
+			}
+
+			return r.Respond(resp)
+		})
+	}
+}
+
 func setUserAgent(client *autorest.Client) {
 	tfVersion := fmt.Sprintf("HashiCorp-Terraform-v%s", terraform.VersionString())
 
@@ -296,8 +523,11 @@ func getAuthorizationToken(c *authentication.Config, oauthConfig *adal.OAuthConf
 }
 
 // getArmClient is a helper method which returns a fully instantiated
-// *ArmClient based on the Config's current settings.
-func getArmClient(c *authentication.Config) (*ArmClient, error) {
+// *ArmClient based on the Config's current settings and the Provider's
+// `options`. Any `decorators` are applied to the Sender used by all of the
+// clients, for example to record the HTTP interactions made during the
+// acceptance tests.
+func getArmClient(c *authentication.Config, options providerOptions, decorators ...autorest.SendDecorator) (*ArmClient, error) {
 	// detect cloud from environment
 	env, envErr := azure.EnvironmentFromName(c.Environment)
 	if envErr != nil {
@@ -317,6 +547,13 @@ func getArmClient(c *authentication.Config) (*ArmClient, error) {
 		environment:              env,
 		usingServicePrincipal:    c.ClientSecret != "",
 		skipProviderRegistration: c.SkipProviderRegistration,
+		schedulerAPIVersion:      options.schedulerAPIVersion,
+		pollingInterval:          options.pollingInterval,
+		requiresImport:           options.requiresImport,
+		ignoreSystemTags:         options.ignoreSystemTags,
+		ignoreForbiddenReads:     options.ignoreForbiddenReads,
+		requiredTags:             options.requiredTags,
+		mysqlOperationsLimiter:   newOperationLimiter(options.maxConcurrentMySQLOps),
 	}
 
 	oauthConfig, err := adal.NewOAuthConfig(env.ActiveDirectoryEndpoint, c.TenantID)
@@ -329,7 +566,12 @@ func getArmClient(c *authentication.Config) (*ArmClient, error) {
 		return nil, fmt.Errorf("Unable to configure OAuthConfig for tenant %s", c.TenantID)
 	}
 
-	sender := autorest.CreateSender(withRequestLogging())
+	sender, err := buildSender(options.caBundlePath, options.requestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	sender = autorest.DecorateSender(sender, decorators...)
+	client.sender = sender
 
 	// Resource Manager endpoints
 	endpoint := env.ResourceManagerEndpoint
@@ -355,37 +597,44 @@ func getArmClient(c *authentication.Config) (*ArmClient, error) {
 		return keyVaultSpt, nil
 	})
 
-	client.registerAppInsightsClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerAutomationClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerAuthentication(endpoint, graphEndpoint, c.SubscriptionID, c.TenantID, auth, graphAuth, sender)
-	client.registerCDNClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerComputeClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerContainerInstanceClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerContainerRegistryClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerContainerServicesClients(endpoint, c.SubscriptionID, auth)
-	client.registerCosmosDBClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerDatabases(endpoint, c.SubscriptionID, auth, sender)
-	client.registerDeviceClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerDNSClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerEventGridClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerEventHubClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerKeyVaultClients(endpoint, c.SubscriptionID, auth, keyVaultAuth, sender)
-	client.registerMonitorClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerNetworkingClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerOperationalInsightsClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerRedisClients(endpoint, c.SubscriptionID, auth, sender)
-	client.registerResourcesClients(endpoint, c.SubscriptionID, auth)
-	client.registerSearchClients(endpoint, c.SubscriptionID, auth)
-	client.registerServiceBusClients(endpoint, c.SubscriptionID, auth)
-	client.registerSchedulerClients(endpoint, c.SubscriptionID, auth)
-	client.registerStorageClients(endpoint, c.SubscriptionID, auth)
-	client.registerTrafficManagerClients(endpoint, c.SubscriptionID, auth)
-	client.registerWebClients(endpoint, c.SubscriptionID, auth)
-	client.registerPolicyClients(endpoint, c.SubscriptionID, auth)
+	client.registerClients(endpoint, graphEndpoint, c.SubscriptionID, c.TenantID, auth, graphAuth, keyVaultAuth, sender)
 
 	return &client, nil
 }
 
+func (c *ArmClient) registerClients(endpoint, graphEndpoint, subscriptionId, tenantId string, auth, graphAuth, keyVaultAuth autorest.Authorizer, sender autorest.Sender) {
+	c.resourceManagerEndpoint = endpoint
+	c.resourceManagerAuthorizer = auth
+
+	c.registerAppInsightsClients(endpoint, subscriptionId, auth, sender)
+	c.registerAutomationClients(endpoint, subscriptionId, auth, sender)
+	c.registerAuthentication(endpoint, graphEndpoint, subscriptionId, tenantId, auth, graphAuth, sender)
+	c.registerCDNClients(endpoint, subscriptionId, auth, sender)
+	c.registerComputeClients(endpoint, subscriptionId, auth, sender)
+	c.registerContainerInstanceClients(endpoint, subscriptionId, auth, sender)
+	c.registerContainerRegistryClients(endpoint, subscriptionId, auth, sender)
+	c.registerContainerServicesClients(endpoint, subscriptionId, auth)
+	c.registerCosmosDBClients(endpoint, subscriptionId, auth, sender)
+	c.registerDatabases(endpoint, subscriptionId, auth, sender)
+	c.registerDeviceClients(endpoint, subscriptionId, auth, sender)
+	c.registerDNSClients(endpoint, subscriptionId, auth, sender)
+	c.registerEventGridClients(endpoint, subscriptionId, auth, sender)
+	c.registerEventHubClients(endpoint, subscriptionId, auth, sender)
+	c.registerKeyVaultClients(endpoint, subscriptionId, auth, keyVaultAuth, sender)
+	c.registerMonitorClients(endpoint, subscriptionId, auth, sender)
+	c.registerNetworkingClients(endpoint, subscriptionId, auth, sender)
+	c.registerOperationalInsightsClients(endpoint, subscriptionId, auth, sender)
+	c.registerRedisClients(endpoint, subscriptionId, auth, sender)
+	c.registerResourcesClients(endpoint, subscriptionId, auth)
+	c.registerSearchClients(endpoint, subscriptionId, auth)
+	c.registerServiceBusClients(endpoint, subscriptionId, auth)
+	c.registerSchedulerClients(endpoint, subscriptionId, auth)
+	c.registerStorageClients(endpoint, subscriptionId, auth)
+	c.registerTrafficManagerClients(endpoint, subscriptionId, auth)
+	c.registerWebClients(endpoint, subscriptionId, auth)
+	c.registerPolicyClients(endpoint, subscriptionId, auth)
+}
+
 func (c *ArmClient) registerAppInsightsClients(endpoint, subscriptionId string, auth autorest.Authorizer, sender autorest.Sender) {
 	ai := appinsights.NewComponentsClientWithBaseURI(endpoint, subscriptionId)
 	setUserAgent(&ai.Client)
@@ -536,11 +785,21 @@ func (c *ArmClient) registerContainerServicesClients(endpoint, subscriptionId st
 
 func (c *ArmClient) registerDatabases(endpoint, subscriptionId string, auth autorest.Authorizer, sender autorest.Sender) {
 	// MySQL
+	mysqlCheckNameAvailabilityClient := mysql.NewCheckNameAvailabilityClientWithBaseURI(endpoint, subscriptionId)
+	setUserAgent(&mysqlCheckNameAvailabilityClient.Client)
+	mysqlCheckNameAvailabilityClient.Authorizer = auth
+	mysqlCheckNameAvailabilityClient.Sender = sender
+	mysqlCheckNameAvailabilityClient.SkipResourceProviderRegistration = c.skipProviderRegistration
+	mysqlCheckNameAvailabilityClient.ResponseInspector = withRequestIDLogging()
+	c.mysqlCheckNameAvailabilityClient = mysqlCheckNameAvailabilityClient
+
 	mysqlConfigClient := mysql.NewConfigurationsClientWithBaseURI(endpoint, subscriptionId)
 	setUserAgent(&mysqlConfigClient.Client)
 	mysqlConfigClient.Authorizer = auth
 	mysqlConfigClient.Sender = sender
 	mysqlConfigClient.SkipResourceProviderRegistration = c.skipProviderRegistration
+	mysqlConfigClient.ResponseInspector = withRequestIDLogging()
+	c.configurePollingInterval(&mysqlConfigClient.Client)
 	c.mysqlConfigurationsClient = mysqlConfigClient
 
 	mysqlDBClient := mysql.NewDatabasesClientWithBaseURI(endpoint, subscriptionId)
@@ -548,6 +807,8 @@ func (c *ArmClient) registerDatabases(endpoint, subscriptionId string, auth auto
 	mysqlDBClient.Authorizer = auth
 	mysqlDBClient.Sender = sender
 	mysqlDBClient.SkipResourceProviderRegistration = c.skipProviderRegistration
+	mysqlDBClient.ResponseInspector = withRequestIDLogging()
+	c.configurePollingInterval(&mysqlDBClient.Client)
 	c.mysqlDatabasesClient = mysqlDBClient
 
 	mysqlFWClient := mysql.NewFirewallRulesClientWithBaseURI(endpoint, subscriptionId)
@@ -555,15 +816,35 @@ func (c *ArmClient) registerDatabases(endpoint, subscriptionId string, auth auto
 	mysqlFWClient.Authorizer = auth
 	mysqlFWClient.Sender = sender
 	mysqlFWClient.SkipResourceProviderRegistration = c.skipProviderRegistration
+	mysqlFWClient.ResponseInspector = withRequestIDLogging()
+	c.configurePollingInterval(&mysqlFWClient.Client)
 	c.mysqlFirewallRulesClient = mysqlFWClient
 
+	mysqlLogFilesClient := mysql.NewLogFilesClientWithBaseURI(endpoint, subscriptionId)
+	setUserAgent(&mysqlLogFilesClient.Client)
+	mysqlLogFilesClient.Authorizer = auth
+	mysqlLogFilesClient.Sender = sender
+	mysqlLogFilesClient.SkipResourceProviderRegistration = c.skipProviderRegistration
+	mysqlLogFilesClient.ResponseInspector = withRequestIDLogging()
+	c.mysqlLogFilesClient = mysqlLogFilesClient
+
 	mysqlServersClient := mysql.NewServersClientWithBaseURI(endpoint, subscriptionId)
 	setUserAgent(&mysqlServersClient.Client)
 	mysqlServersClient.Authorizer = auth
 	mysqlServersClient.Sender = sender
 	mysqlServersClient.SkipResourceProviderRegistration = c.skipProviderRegistration
+	mysqlServersClient.ResponseInspector = withRequestIDLogging()
+	c.configurePollingInterval(&mysqlServersClient.Client)
 	c.mysqlServersClient = mysqlServersClient
 
+	mysqlPerformanceTiersClient := mysql.NewLocationBasedPerformanceTierClientWithBaseURI(endpoint, subscriptionId)
+	setUserAgent(&mysqlPerformanceTiersClient.Client)
+	mysqlPerformanceTiersClient.Authorizer = auth
+	mysqlPerformanceTiersClient.Sender = sender
+	mysqlPerformanceTiersClient.SkipResourceProviderRegistration = c.skipProviderRegistration
+	mysqlPerformanceTiersClient.ResponseInspector = withRequestIDLogging()
+	c.mysqlPerformanceTiersClient = mysqlPerformanceTiersClient
+
 	// PostgreSQL
 	postgresqlConfigClient := postgresql.NewConfigurationsClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&postgresqlConfigClient.Client, auth)
@@ -688,10 +969,12 @@ func (c *ArmClient) registerKeyVaultClients(endpoint, subscriptionId string, aut
 
 func (c *ArmClient) registerMonitorClients(endpoint, subscriptionId string, auth autorest.Authorizer, sender autorest.Sender) {
 	arc := insights.NewAlertRulesClientWithBaseURI(endpoint, subscriptionId)
-	setUserAgent(&arc.Client)
-	arc.Authorizer = auth
-	arc.Sender = autorest.CreateSender(withRequestLogging())
+	c.configureClient(&arc.Client, auth)
 	c.monitorAlertRulesClient = arc
+
+	dsc := insights.NewDiagnosticSettingsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&dsc.Client, auth)
+	c.monitorDiagnosticSettingsClient = dsc
 }
 
 func (c *ArmClient) registerNetworkingClients(endpoint, subscriptionId string, auth autorest.Authorizer, sender autorest.Sender) {
@@ -850,10 +1133,51 @@ func (c *ArmClient) registerServiceBusClients(endpoint, subscriptionId string, a
 	c.serviceBusSubscriptionsClient = subscriptionsClient
 }
 
+// schedulerDefaultAPIVersion is the API Version used by the vendored Scheduler SDK
+const schedulerDefaultAPIVersion = "2016-03-01"
+
+// schedulerJobCollectionsCacheTTL is how long a Job Collection read from the API is reused for
+const schedulerJobCollectionsCacheTTL = 30 * time.Second
+
 func (c *ArmClient) registerSchedulerClients(endpoint, subscriptionId string, auth autorest.Authorizer) {
-	jobsClient := scheduler.NewJobCollectionsClientWithBaseURI(endpoint, subscriptionId)
+	log.Printf("[DEBUG] Using API Version %q for the Scheduler clients", c.schedulerClientAPIVersion())
+
+	c.schedulerJobCollectionsClient = c.newSchedulerJobCollectionsClient(endpoint, subscriptionId, auth)
+	c.schedulerJobCollectionsCache = newResourceCache(schedulerJobCollectionsCacheTTL)
+
+	jobsClient := scheduler.NewJobsClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&jobsClient.Client, auth)
-	c.schedulerJobCollectionsClient = jobsClient
+	jobsClient.RequestInspector = withAPIVersion(c.schedulerClientAPIVersion())
+	jobsClient.ResponseInspector = withRequestIDLogging()
+	c.configurePollingInterval(&jobsClient.Client)
+	c.schedulerJobsClient = jobsClient
+}
+
+func (c *ArmClient) schedulerClientAPIVersion() string {
+	if c.schedulerAPIVersion == "" {
+		return schedulerDefaultAPIVersion
+	}
+
+	return c.schedulerAPIVersion
+}
+
+func (c *ArmClient) newSchedulerJobCollectionsClient(endpoint, subscriptionId string, auth autorest.Authorizer) scheduler.JobCollectionsClient {
+	collectionsClient := scheduler.NewJobCollectionsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&collectionsClient.Client, auth)
+	collectionsClient.RequestInspector = withAPIVersion(c.schedulerClientAPIVersion())
+	collectionsClient.ResponseInspector = withRequestIDLogging()
+	c.configurePollingInterval(&collectionsClient.Client)
+	return collectionsClient
+}
+
+// schedulerJobCollectionsClientForSubscription returns a Job Collections client for the specified subscription, using
+// the same credentials as the Provider - when no subscription is specified the Provider's subscription is used.
+func (c *ArmClient) schedulerJobCollectionsClientForSubscription(subscriptionId string) scheduler.JobCollectionsClient {
+	if subscriptionId == "" || strings.EqualFold(subscriptionId, c.subscriptionId) {
+		return c.schedulerJobCollectionsClient
+	}
+
+	return c.newSchedulerJobCollectionsClient(c.resourceManagerEndpoint, subscriptionId, c.resourceManagerAuthorizer)
 }
 
 func (c *ArmClient) registerStorageClients(endpoint, subscriptionId string, auth autorest.Authorizer) {
@@ -0,0 +1,432 @@
+package azurerm
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/scheduler/mgmt/2016-03-01/scheduler"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestWithAPIVersion(t *testing.T) {
+	testCases := []struct {
+		url        string
+		apiVersion string
+		expected   string
+	}{
+		{
+			url:        "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000?api-version=2016-03-01",
+			apiVersion: "2016-03-01",
+			expected:   "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000?api-version=2016-03-01",
+		},
+		{
+			url:        "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000?api-version=2016-03-01",
+			apiVersion: "2017-01-01-preview",
+			expected:   "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000?api-version=2017-01-01-preview",
+		},
+		{
+			url:        "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000?api-version=2016-03-01",
+			apiVersion: "",
+			expected:   "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000?api-version=2016-03-01",
+		},
+		{
+			// polling URI's without an api-version are left as-is
+			url:        "https://management.azure.com/operationResults/abc123",
+			apiVersion: "2017-01-01-preview",
+			expected:   "https://management.azure.com/operationResults/abc123",
+		},
+	}
+
+	for _, test := range testCases {
+		req, err := http.NewRequest(http.MethodGet, test.url, nil)
+		if err != nil {
+			t.Fatalf("Error building request: %+v", err)
+		}
+
+		req, err = autorest.Prepare(req, withAPIVersion(test.apiVersion))
+		if err != nil {
+			t.Fatalf("Error preparing request: %+v", err)
+		}
+
+		if actual := req.URL.String(); actual != test.expected {
+			t.Fatalf("Expected the URL to be %q but got %q", test.expected, actual)
+		}
+	}
+}
+
+func TestConfigurePollingInterval(t *testing.T) {
+	testCases := []struct {
+		pollingInterval time.Duration
+		expected        time.Duration
+	}{
+		{0, autorest.DefaultPollingDelay},
+		{-1 * time.Second, autorest.DefaultPollingDelay},
+		{5 * time.Second, 5 * time.Second},
+		{120 * time.Second, 120 * time.Second},
+	}
+
+	for _, test := range testCases {
+		armClient := ArmClient{
+			pollingInterval: test.pollingInterval,
+		}
+
+		client := autorest.NewClientWithUserAgent("")
+		armClient.configurePollingInterval(&client)
+
+		if client.PollingDelay != test.expected {
+			t.Fatalf("Expected the Polling Delay for a `polling_interval` of %s to be %s but got %s", test.pollingInterval, test.expected, client.PollingDelay)
+		}
+	}
+}
+
+func TestConfigurePollingInterval_honoured(t *testing.T) {
+	// the SDK rounds the polling delay down to whole seconds
+	pollingInterval := 1 * time.Second
+	armClient := ArmClient{
+		pollingInterval: pollingInterval,
+	}
+
+	requestTimes := make([]time.Time, 0)
+	client := scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+	armClient.configurePollingInterval(&client.Client)
+	client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		requestTimes = append(requestTimes, time.Now())
+
+		// the deletion is accepted (without a Retry-After header) and is still in progress when first polled,
+		// which happens immediately - so it's the delay before the next poll which is the polling interval
+		statusCode := http.StatusOK
+		header := http.Header{}
+		if len(requestTimes) <= 2 {
+			statusCode = http.StatusAccepted
+			header.Set("Location", "https://management.azure.com/operations/delete")
+		}
+
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Request:    r,
+		}, nil
+	})
+
+	if err := deleteSchedulerJobCollection(context.Background(), client, "group1", "collection1", false); err != nil {
+		t.Fatalf("Expected no error deleting but got: %+v", err)
+	}
+
+	if len(requestTimes) != 3 {
+		t.Fatalf("Expected 3 requests but got %d", len(requestTimes))
+	}
+
+	if delay := requestTimes[2].Sub(requestTimes[1]); delay < pollingInterval {
+		t.Fatalf("Expected the operation to be polled after at least %s but was polled after %s", pollingInterval, delay)
+	}
+}
+
+func TestWithRequestIDLogging(t *testing.T) {
+	testCases := []struct {
+		requestId string
+		expected  string
+	}{
+		{
+			requestId: "00000000-0000-0000-0000-000000000001",
+			expected:  `AzureRM Request ID "00000000-0000-0000-0000-000000000001": GET https://management.azure.com/example returned 200`,
+		},
+		{
+			requestId: "",
+			expected:  "",
+		},
+	}
+
+	for _, test := range testCases {
+		var buf bytes.Buffer
+		log.SetOutput(&buf)
+
+		req, err := http.NewRequest(http.MethodGet, "https://management.azure.com/example", nil)
+		if err != nil {
+			t.Fatalf("Error building request: %+v", err)
+		}
+
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}
+		if test.requestId != "" {
+			resp.Header.Set("x-ms-request-id", test.requestId)
+		}
+
+		err = autorest.Respond(resp, withRequestIDLogging())
+		log.SetOutput(os.Stderr)
+		if err != nil {
+			t.Fatalf("Error responding: %+v", err)
+		}
+
+		output := buf.String()
+		if test.expected == "" {
+			if output != "" {
+				t.Fatalf("Expected nothing to be logged but got %q", output)
+			}
+			continue
+		}
+
+		if !strings.Contains(output, test.expected) {
+			t.Fatalf("Expected the log output to contain %q but got %q", test.expected, output)
+		}
+	}
+}
+
+func TestLoadCABundle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "azurerm-ca-bundle")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	validPath := filepath.Join(dir, "valid.pem")
+	if err := ioutil.WriteFile(validPath, testGenerateCertificatePEM(t), 0600); err != nil {
+		t.Fatalf("Error writing CA Bundle: %+v", err)
+	}
+
+	invalidPath := filepath.Join(dir, "invalid.pem")
+	if err := ioutil.WriteFile(invalidPath, []byte("not a certificate"), 0600); err != nil {
+		t.Fatalf("Error writing CA Bundle: %+v", err)
+	}
+
+	testCases := []struct {
+		path        string
+		shouldError bool
+	}{
+		{validPath, false},
+		{invalidPath, true},
+		{filepath.Join(dir, "missing.pem"), true},
+	}
+
+	for _, test := range testCases {
+		pool, err := loadCABundle(test.path)
+		if test.shouldError {
+			if err == nil {
+				t.Fatalf("Expected an error loading the CA Bundle %q but didn't get one", test.path)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Expected no error loading the CA Bundle %q but got: %+v", test.path, err)
+		}
+
+		if pool == nil {
+			t.Fatalf("Expected a certificate pool for the CA Bundle %q but got nil", test.path)
+		}
+	}
+
+	if _, err := buildSender(invalidPath, 0); err == nil {
+		t.Fatalf("Expected an error building the Sender with an invalid CA Bundle but didn't get one")
+	}
+}
+
+type testSharedSender struct{}
+
+func (s *testSharedSender) Do(r *http.Request) (*http.Response, error) {
+	return nil, nil
+}
+
+func TestRegisterClients_sharedSender(t *testing.T) {
+	sender := &testSharedSender{}
+	client := ArmClient{
+		sender: sender,
+	}
+
+	endpoint := "https://management.azure.com"
+	subscriptionId := "00000000-0000-0000-0000-000000000000"
+	auth := autorest.NullAuthorizer{}
+
+	client.registerSchedulerClients(endpoint, subscriptionId, auth)
+	client.registerDatabases(endpoint, subscriptionId, auth, client.sender)
+	client.registerMonitorClients(endpoint, subscriptionId, auth, client.sender)
+
+	senders := map[string]autorest.Sender{
+		"schedulerJobCollectionsClient":    client.schedulerJobCollectionsClient.Sender,
+		"schedulerJobsClient":              client.schedulerJobsClient.Sender,
+		"mysqlCheckNameAvailabilityClient": client.mysqlCheckNameAvailabilityClient.Sender,
+		"mysqlConfigurationsClient":        client.mysqlConfigurationsClient.Sender,
+		"mysqlDatabasesClient":             client.mysqlDatabasesClient.Sender,
+		"mysqlFirewallRulesClient":         client.mysqlFirewallRulesClient.Sender,
+		"mysqlLogFilesClient":              client.mysqlLogFilesClient.Sender,
+		"mysqlServersClient":               client.mysqlServersClient.Sender,
+		"mysqlPerformanceTiersClient":      client.mysqlPerformanceTiersClient.Sender,
+		"monitorAlertRulesClient":          client.monitorAlertRulesClient.Sender,
+		"monitorDiagnosticSettingsClient":  client.monitorDiagnosticSettingsClient.Sender,
+	}
+
+	for name, actual := range senders {
+		if actual != autorest.Sender(sender) {
+			t.Fatalf("Expected %s to use the shared Sender", name)
+		}
+	}
+}
+
+func TestRegisterClients_skipProviderRegistration(t *testing.T) {
+	for _, skip := range []bool{true, false} {
+		registrations := 0
+		unregistered := map[string]bool{
+			"Microsoft.Scheduler":  true,
+			"Microsoft.DBforMySQL": true,
+		}
+
+		sender := autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			status := http.StatusOK
+			body := `{"registrationState": "Registered"}`
+
+			provider := ""
+			segments := strings.Split(r.URL.Path, "/")
+			for i, segment := range segments {
+				if segment == "providers" && i+1 < len(segments) {
+					provider = segments[i+1]
+				}
+			}
+
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/register"):
+				registrations++
+				unregistered[provider] = false
+			case strings.Contains(r.URL.Path, "/resourceGroups/") && unregistered[provider]:
+				status = http.StatusConflict
+				body = fmt.Sprintf(`{"error": {"code": "MissingSubscriptionRegistration", "message": "Not registered", "details": [{"target": %q}]}}`, provider)
+			}
+
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    r,
+			}, nil
+		})
+
+		client := ArmClient{
+			sender:                   sender,
+			skipProviderRegistration: skip,
+		}
+
+		endpoint := "https://management.azure.com"
+		subscriptionId := "00000000-0000-0000-0000-000000000000"
+		auth := autorest.NullAuthorizer{}
+
+		client.registerSchedulerClients(endpoint, subscriptionId, auth)
+		client.registerDatabases(endpoint, subscriptionId, auth, client.sender)
+
+		ctx := context.Background()
+		_, schedulerErr := client.schedulerJobCollectionsClient.Get(ctx, "group1", "collection1")
+		_, mysqlErr := client.mysqlServersClient.Get(ctx, "group1", "server1")
+
+		if skip {
+			if registrations != 0 {
+				t.Fatalf("Expected no Resource Providers to be registered when skipping registration but got %d", registrations)
+			}
+			if schedulerErr == nil || mysqlErr == nil {
+				t.Fatalf("Expected the requests to fail when skipping registration")
+			}
+			continue
+		}
+
+		if registrations != 2 {
+			t.Fatalf("Expected both Resource Providers to be registered but got %d", registrations)
+		}
+		if schedulerErr != nil || mysqlErr != nil {
+			t.Fatalf("Expected the requests to succeed once registered but got: %+v / %+v", schedulerErr, mysqlErr)
+		}
+	}
+}
+
+func testGenerateCertificatePEM(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating key: %+v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %+v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestBuildSender_requestTimeout(t *testing.T) {
+	testCases := []struct {
+		method           string
+		stalledRequests  int
+		shouldError      bool
+		expectedRequests int
+	}{
+		{http.MethodGet, 0, false, 1},
+		{http.MethodGet, 1, false, 2},
+		{http.MethodGet, requestTimeoutRetryAttempts, true, requestTimeoutRetryAttempts},
+		{http.MethodHead, 1, false, 2},
+		// requests which aren't idempotent may have been applied, so aren't retried
+		{http.MethodPut, 1, true, 1},
+		{http.MethodDelete, 1, true, 1},
+	}
+
+	for _, test := range testCases {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if int(atomic.AddInt32(&requests, 1)) <= test.stalledRequests {
+				time.Sleep(500 * time.Millisecond)
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		sender, err := buildSender("", 100*time.Millisecond)
+		if err != nil {
+			t.Fatalf("Error building Sender: %+v", err)
+		}
+
+		req, err := http.NewRequest(test.method, server.URL, nil)
+		if err != nil {
+			t.Fatalf("Error building request: %+v", err)
+		}
+
+		_, err = sender.Do(req)
+		server.Close()
+
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected an error when %d %s requests stall but didn't get one", test.stalledRequests, test.method)
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected no error when %d %s requests stall but got: %+v", test.stalledRequests, test.method, err)
+		}
+
+		if actual := int(atomic.LoadInt32(&requests)); actual != test.expectedRequests {
+			t.Fatalf("Expected %d requests when %d %s requests stall but got %d", test.expectedRequests, test.stalledRequests, test.method, actual)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-04-30-preview/mysql"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmMySQLDatabases() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmMySQLDatabasesRead,
+
+		Schema: map[string]*schema.Schema{
+			"server_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"databases": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"charset": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"collation": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmMySQLDatabasesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).mysqlDatabasesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	serverName := d.Get("server_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	log.Printf("[DEBUG] Reading Databases for MySQL Server %q (Resource Group %q)", serverName, resourceGroup)
+
+	// NOTE: this API version returns all of the Databases in a single (non-paged) response
+	resp, err := client.ListByServer(ctx, resourceGroup, serverName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("Error: MySQL Server %q (Resource Group %q) was not found", serverName, resourceGroup)
+		}
+
+		return fmt.Errorf("Error listing Databases for MySQL Server %q (Resource Group %q): %+v", serverName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("databases", flattenMySQLDatabases(resp.Value)); err != nil {
+		return fmt.Errorf("Error setting `databases`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenMySQLDatabases(input *[]mysql.Database) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, database := range *input {
+		output := make(map[string]interface{}, 0)
+
+		if database.Name != nil {
+			output["name"] = *database.Name
+		}
+
+		if props := database.DatabaseProperties; props != nil {
+			if props.Charset != nil {
+				output["charset"] = *props.Charset
+			}
+
+			if props.Collation != nil {
+				output["collation"] = *props.Collation
+			}
+		}
+
+		results = append(results, output)
+	}
+
+	return results
+}
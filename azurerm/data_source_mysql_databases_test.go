@@ -0,0 +1,75 @@
+package azurerm
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDataSourceAzureRMMySQLDatabases_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_mysql_databases.test"
+	ri := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMySQLDatabases_basic(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					// the server also contains the system databases, so these are checked for the one we've created
+					resource.TestCheckResourceAttrSet(dataSourceName, "databases.#"),
+					testCheckAzureRMMySQLDatabasesContains(dataSourceName, fmt.Sprintf("acctestdb_%d", ri), "utf8", "utf8_unicode_ci"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMMySQLDatabasesContains(name, databaseName, charset, collation string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		attributes := rs.Primary.Attributes
+		count, err := strconv.Atoi(attributes["databases.#"])
+		if err != nil {
+			return fmt.Errorf("Error parsing the number of databases: %+v", err)
+		}
+
+		for i := 0; i < count; i++ {
+			if attributes[fmt.Sprintf("databases.%d.name", i)] != databaseName {
+				continue
+			}
+
+			if v := attributes[fmt.Sprintf("databases.%d.charset", i)]; v != charset {
+				return fmt.Errorf("Expected the charset of Database %q to be %q but got %q", databaseName, charset, v)
+			}
+
+			if v := attributes[fmt.Sprintf("databases.%d.collation", i)]; v != collation {
+				return fmt.Errorf("Expected the collation of Database %q to be %q but got %q", databaseName, collation, v)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("Database %q was not found in %q", databaseName, name)
+	}
+}
+
+func testAccDataSourceMySQLDatabases_basic(rInt int, location string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_mysql_databases" "test" {
+  server_name         = "${azurerm_mysql_database.test.server_name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+`, testAccAzureRMMySQLDatabase_basic(rInt, location))
+}
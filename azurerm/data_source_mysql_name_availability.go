@@ -0,0 +1,67 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-04-30-preview/mysql"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmMySQLNameAvailability() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmMySQLNameAvailabilityRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"available": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceArmMySQLNameAvailabilityRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).mysqlCheckNameAvailabilityClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Checking the availability of the MySQL Server name %q", name)
+
+	parameters := mysql.NameAvailabilityRequest{
+		Name: utils.String(name),
+		Type: utils.String("Microsoft.DBforMySQL/servers"),
+	}
+	resp, err := client.Execute(ctx, parameters)
+	if err != nil {
+		return fmt.Errorf("Error checking the availability of the MySQL Server name %q: %+v", name, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if available := resp.NameAvailable; available != nil {
+		d.Set("available", *available)
+	}
+	d.Set("reason", resp.Reason)
+	d.Set("message", resp.Message)
+
+	return nil
+}
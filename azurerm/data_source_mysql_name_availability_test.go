@@ -0,0 +1,65 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMMySQLNameAvailability_available(t *testing.T) {
+	dataSourceName := "data.azurerm_mysql_name_availability.test"
+	ri := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMySQLNameAvailability_available(ri),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "available", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAzureRMMySQLNameAvailability_unavailable(t *testing.T) {
+	dataSourceName := "data.azurerm_mysql_name_availability.test"
+	ri := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMySQLNameAvailability_unavailable(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "available", "false"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "reason"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "message"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMySQLNameAvailability_available(rInt int) string {
+	return fmt.Sprintf(`
+data "azurerm_mysql_name_availability" "test" {
+  name = "acctestmysqlsvr-%d"
+}
+`, rInt)
+}
+
+func testAccDataSourceMySQLNameAvailability_unavailable(rInt int, location string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_mysql_name_availability" "test" {
+  name = "${azurerm_mysql_server.test.name}"
+}
+`, testAccAzureRMMySQLServer_basicFiveSeven(rInt, location))
+}
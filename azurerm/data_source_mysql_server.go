@@ -0,0 +1,174 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmMySqlServer() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmMySqlServerRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"location": locationForDataSourceSchema(),
+
+			"sku": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"capacity": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"tier": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"administrator_login": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"storage_mb": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"ssl_enforcement": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"query_store": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"capture_mode": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"wait_sampling_capture_mode": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"connection_string": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ado_net_connection_string": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"jdbc_connection_string": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsForDataSourceSchema(),
+		},
+	}
+}
+
+func dataSourceArmMySqlServerRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).mysqlServersClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("Error: MySQL Server %q (Resource Group %q) was not found", name, resourceGroup)
+		}
+
+		return fmt.Errorf("Error making Read request on Azure MySQL Server %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.SetId(*resp.ID)
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resourceGroup)
+
+	if location := resp.Location; location != nil {
+		d.Set("location", azureRMNormalizeLocation(*location))
+	}
+
+	if props := resp.ServerProperties; props != nil {
+		d.Set("administrator_login", props.AdministratorLogin)
+		d.Set("version", string(props.Version))
+		if props.StorageMB != nil {
+			d.Set("storage_mb", int(*props.StorageMB))
+		}
+		d.Set("ssl_enforcement", string(props.SslEnforcement))
+		d.Set("fqdn", props.FullyQualifiedDomainName)
+
+		if fqdn := props.FullyQualifiedDomainName; fqdn != nil && props.AdministratorLogin != nil {
+			connectionStrings := mysqlServerConnectionStrings(*fqdn, name, *props.AdministratorLogin)
+			for k, v := range connectionStrings {
+				d.Set(k, v)
+			}
+		}
+	}
+
+	if resp.Sku != nil {
+		if err := d.Set("sku", flattenMySQLServerSku(d, resp.Sku)); err != nil {
+			return fmt.Errorf("Error setting `sku`: %+v", err)
+		}
+	}
+
+	// the Query Store is omitted when it's using its default settings
+	queryStore, isDefault, err := getMySQLServerQueryStore(ctx, meta.(*ArmClient).mysqlConfigurationsClient, resourceGroup, name)
+	if err != nil {
+		return err
+	}
+	queryStoreBlock := make([]interface{}, 0)
+	if !isDefault {
+		queryStoreBlock = append(queryStoreBlock, queryStore)
+	}
+	if err := d.Set("query_store", queryStoreBlock); err != nil {
+		return fmt.Errorf("Error setting `query_store`: %+v", err)
+	}
+
+	flattenAndSetTagsIgnoringSystemTags(d, resp.Tags, meta.(*ArmClient).ignoreSystemTags)
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-04-30-preview/mysql"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmMySQLServerConnectivity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmMySQLServerConnectivityRead,
+
+		Schema: map[string]*schema.Schema{
+			"server_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"accepting_connections": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"ssl_enforcement": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_network_access_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"firewall_rule_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"azure_services_allowed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceArmMySQLServerConnectivityRead(d *schema.ResourceData, meta interface{}) error {
+	serversClient := meta.(*ArmClient).mysqlServersClient
+	firewallRulesClient := meta.(*ArmClient).mysqlFirewallRulesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	serverName := d.Get("server_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	log.Printf("[DEBUG] Reading Connectivity for MySQL Server %q (Resource Group %q)", serverName, resourceGroup)
+
+	server, err := serversClient.Get(ctx, resourceGroup, serverName)
+	if err != nil {
+		if utils.ResponseWasNotFound(server.Response) {
+			return fmt.Errorf("Error: MySQL Server %q (Resource Group %q) was not found", serverName, resourceGroup)
+		}
+
+		return fmt.Errorf("Error retrieving MySQL Server %q (Resource Group %q): %+v", serverName, resourceGroup, err)
+	}
+
+	// NOTE: this API version returns all of the Firewall Rules in a single (non-paged) response
+	rules, err := firewallRulesClient.ListByServer(ctx, resourceGroup, serverName)
+	if err != nil {
+		return fmt.Errorf("Error listing Firewall Rules for MySQL Server %q (Resource Group %q): %+v", serverName, resourceGroup, err)
+	}
+
+	d.SetId(*server.ID)
+
+	if props := server.ServerProperties; props != nil {
+		d.Set("fqdn", props.FullyQualifiedDomainName)
+		d.Set("state", string(props.UserVisibleState))
+		d.Set("accepting_connections", props.UserVisibleState == mysql.Ready)
+		d.Set("ssl_enforcement", string(props.SslEnforcement))
+	}
+
+	summary := summariseMySQLServerFirewallRules(rules.Value)
+	d.Set("firewall_rule_count", summary.ruleCount)
+	d.Set("azure_services_allowed", summary.azureServicesAllowed)
+	// this API version has no separate setting for public network access - it's granted by Firewall Rules
+	d.Set("public_network_access_enabled", summary.ruleCount > 0)
+
+	return nil
+}
+
+type mysqlServerFirewallSummary struct {
+	ruleCount            int
+	azureServicesAllowed bool
+}
+
+func summariseMySQLServerFirewallRules(input *[]mysql.FirewallRule) mysqlServerFirewallSummary {
+	summary := mysqlServerFirewallSummary{}
+	if input == nil {
+		return summary
+	}
+
+	for _, rule := range *input {
+		summary.ruleCount++
+
+		props := rule.FirewallRuleProperties
+		if props == nil || props.StartIPAddress == nil || props.EndIPAddress == nil {
+			continue
+		}
+
+		// a rule of `0.0.0.0` - `0.0.0.0` allows access from Azure Services
+		if *props.StartIPAddress == "0.0.0.0" && *props.EndIPAddress == "0.0.0.0" {
+			summary.azureServicesAllowed = true
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,104 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-04-30-preview/mysql"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestSummariseMySQLServerFirewallRules(t *testing.T) {
+	rule := func(start, end string) mysql.FirewallRule {
+		return mysql.FirewallRule{
+			FirewallRuleProperties: &mysql.FirewallRuleProperties{
+				StartIPAddress: utils.String(start),
+				EndIPAddress:   utils.String(end),
+			},
+		}
+	}
+
+	cases := []struct {
+		Name     string
+		Input    *[]mysql.FirewallRule
+		Expected mysqlServerFirewallSummary
+	}{
+		{
+			Name:     "nil",
+			Input:    nil,
+			Expected: mysqlServerFirewallSummary{},
+		},
+		{
+			Name: "public rules",
+			Input: &[]mysql.FirewallRule{
+				rule("10.0.0.1", "10.0.0.10"),
+				rule("192.168.0.1", "192.168.0.1"),
+			},
+			Expected: mysqlServerFirewallSummary{
+				ruleCount: 2,
+			},
+		},
+		{
+			Name: "azure services",
+			Input: &[]mysql.FirewallRule{
+				rule("10.0.0.1", "10.0.0.10"),
+				rule("0.0.0.0", "0.0.0.0"),
+			},
+			Expected: mysqlServerFirewallSummary{
+				ruleCount:            2,
+				azureServicesAllowed: true,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		if actual := summariseMySQLServerFirewallRules(tc.Input); actual != tc.Expected {
+			t.Fatalf("%s: Expected %+v but got %+v", tc.Name, tc.Expected, actual)
+		}
+	}
+}
+
+func TestAccDataSourceAzureRMMySQLServerConnectivity_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_mysql_server_connectivity.test"
+	ri := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMySQLServerConnectivity_basic(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "fqdn"),
+					resource.TestCheckResourceAttr(dataSourceName, "state", "Ready"),
+					resource.TestCheckResourceAttr(dataSourceName, "accepting_connections", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "ssl_enforcement", "Enabled"),
+					resource.TestCheckResourceAttr(dataSourceName, "public_network_access_enabled", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "firewall_rule_count", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "azure_services_allowed", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMySQLServerConnectivity_basic(rInt int, location string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mysql_firewall_rule" "test" {
+  name                = "acctestfwrule-%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  server_name         = "${azurerm_mysql_server.test.name}"
+  start_ip_address    = "0.0.0.0"
+  end_ip_address      = "0.0.0.0"
+}
+
+data "azurerm_mysql_server_connectivity" "test" {
+  server_name         = "${azurerm_mysql_firewall_rule.test.server_name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+`, testAccAzureRMMySQLServer_basicFiveSeven(rInt, location), rInt)
+}
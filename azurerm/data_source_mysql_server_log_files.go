@@ -0,0 +1,112 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-04-30-preview/mysql"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmMySQLServerLogFiles() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmMySQLServerLogFilesRead,
+
+		Schema: map[string]*schema.Schema{
+			"server_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"log_files": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size_in_kb": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"created_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"download_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmMySQLServerLogFilesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).mysqlLogFilesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	serverName := d.Get("server_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	log.Printf("[DEBUG] Reading Log Files for MySQL Server %q (Resource Group %q)", serverName, resourceGroup)
+
+	// NOTE: this API version returns all of the Log Files in a single (non-paged) response
+	resp, err := client.ListByServer(ctx, resourceGroup, serverName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("Error: MySQL Server %q (Resource Group %q) was not found", serverName, resourceGroup)
+		}
+
+		return fmt.Errorf("Error listing Log Files for MySQL Server %q (Resource Group %q): %+v", serverName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("log_files", flattenMySQLServerLogFiles(resp.Value)); err != nil {
+		return fmt.Errorf("Error setting `log_files`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenMySQLServerLogFiles(input *[]mysql.LogFile) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, file := range *input {
+		output := make(map[string]interface{}, 0)
+
+		if file.Name != nil {
+			output["name"] = *file.Name
+		}
+
+		if props := file.LogFileProperties; props != nil {
+			if props.SizeInKB != nil {
+				output["size_in_kb"] = int(*props.SizeInKB)
+			}
+
+			if props.CreatedTime != nil {
+				output["created_time"] = props.CreatedTime.Format(time.RFC3339)
+			}
+
+			if props.URL != nil {
+				output["download_url"] = *props.URL
+			}
+		}
+
+		results = append(results, output)
+	}
+
+	return results
+}
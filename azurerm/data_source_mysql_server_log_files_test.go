@@ -0,0 +1,38 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMMySQLServerLogFiles_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_mysql_server_log_files.test"
+	ri := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMySQLServerLogFiles_basic(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "log_files.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMySQLServerLogFiles_basic(rInt int, location string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_mysql_server_log_files" "test" {
+  server_name         = "${azurerm_mysql_server.test.name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+`, testAccAzureRMMySQLServer_basicFiveSeven(rInt, location))
+}
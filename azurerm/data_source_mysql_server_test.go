@@ -0,0 +1,51 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMMySQLServer_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_mysql_server.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMySQLServer_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "location"),
+					resource.TestCheckResourceAttr(dataSourceName, "sku.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "sku.0.name", "MYSQLB50"),
+					resource.TestCheckResourceAttr(dataSourceName, "sku.0.capacity", "50"),
+					resource.TestCheckResourceAttr(dataSourceName, "sku.0.tier", "Basic"),
+					resource.TestCheckResourceAttr(dataSourceName, "administrator_login", "acctestun"),
+					resource.TestCheckResourceAttr(dataSourceName, "version", "5.7"),
+					resource.TestCheckResourceAttr(dataSourceName, "storage_mb", "51200"),
+					resource.TestCheckResourceAttr(dataSourceName, "ssl_enforcement", "Enabled"),
+					resource.TestCheckResourceAttr(dataSourceName, "query_store.#", "0"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "fqdn", "azurerm_mysql_server.test", "fqdn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "connection_string", "azurerm_mysql_server.test", "connection_string"),
+					resource.TestCheckResourceAttr(dataSourceName, "tags.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMySQLServer_basic(rInt int, location string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_mysql_server" "test" {
+  name                = "${azurerm_mysql_server.test.name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+`, testAccAzureRMMySQLServer_basicFiveSeven(rInt, location))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
@@ -16,14 +17,22 @@ func dataSourceArmSchedulerJobCollection() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateSchedulerRetirement(nil),
 			},
 
 			"location": locationForDataSourceSchema(),
 
 			"resource_group_name": resourceGroupNameForDataSourceSchema(),
 
+			"subscription_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateUUID,
+			},
+
 			"tags": tagsForDataSourceSchema(),
 
 			"sku": {
@@ -68,12 +77,15 @@ func dataSourceArmSchedulerJobCollection() *schema.Resource {
 }
 
 func dataSourceArmSchedulerJobCollectionRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).schedulerJobCollectionsClient
 	ctx := meta.(*ArmClient).StopContext
 
 	resourceGroup := d.Get("resource_group_name").(string)
 	name := d.Get("name").(string)
 
+	//the collection can be in another subscription, which is accessed using the Provider's credentials
+	subscriptionId := d.Get("subscription_id").(string)
+	client := meta.(*ArmClient).schedulerJobCollectionsClientForSubscription(subscriptionId)
+
 	collection, err := client.Get(ctx, resourceGroup, name)
 	if err != nil {
 		if utils.ResponseWasNotFound(collection.Response) {
@@ -81,16 +93,27 @@ func dataSourceArmSchedulerJobCollectionRead(d *schema.ResourceData, meta interf
 			return nil
 		}
 
+		if response.WasForbidden(collection.Response.Response) {
+			return fmt.Errorf("The credentials used by the Provider don't have permission to read Scheduler Job Collection %q (Resource Group %q) in Subscription %q: %s", name, resourceGroup, client.SubscriptionID, formatARMError(err))
+		}
+
+		if unavailableErr := schedulerServiceUnavailableError(err, ""); unavailableErr != nil {
+			return fmt.Errorf("Error making Read request on Scheduler Job Collection %q (Resource Group %q): %+v", name, resourceGroup, unavailableErr)
+		}
+
 		return fmt.Errorf("Error making Read request on Scheduler Job Collection %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
 	d.SetId(*collection.ID)
 
-	//standard properties
+	//standard properties - the location isn't an input, it's whichever region the collection exists in
 	d.Set("name", collection.Name)
-	d.Set("location", azureRMNormalizeLocation(*collection.Location))
+	if location := collection.Location; location != nil {
+		d.Set("location", azureRMNormalizeLocation(*location))
+	}
 	d.Set("resource_group_name", resourceGroup)
-	flattenAndSetTags(d, collection.Tags)
+	d.Set("subscription_id", client.SubscriptionID)
+	flattenAndSetTagsIgnoringSystemTags(d, collection.Tags, meta.(*ArmClient).ignoreSystemTags)
 
 	//resource specific
 	if properties := collection.Properties; properties != nil {
@@ -1,11 +1,18 @@
 package azurerm
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 )
 
 func TestAccDataSourceAzureRMSchedulerJobCollection_basic(t *testing.T) {
@@ -18,7 +25,10 @@ func TestAccDataSourceAzureRMSchedulerJobCollection_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceSchedulerJobCollection_basic(ri, testLocation()),
-				Check:  checkAccAzureRMSchedulerJobCollection_basic(dataSourceName),
+				Check: resource.ComposeTestCheckFunc(
+					checkAccAzureRMSchedulerJobCollection_basic(dataSourceName),
+					resource.TestCheckResourceAttrPair(dataSourceName, "location", "azurerm_scheduler_job_collection.test", "location"),
+				),
 			},
 		},
 	})
@@ -40,6 +50,89 @@ func TestAccDataSourceAzureRMSchedulerJobCollection_complete(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceAzureRMSchedulerJobCollection_subscriptionId(t *testing.T) {
+	dataSourceName := "data.azurerm_scheduler_job_collection.test"
+	ri := acctest.RandInt()
+	subscriptionId := os.Getenv("ARM_SUBSCRIPTION_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSchedulerJobCollection_subscriptionId(ri, testLocation(), subscriptionId),
+				Check: resource.ComposeTestCheckFunc(
+					checkAccAzureRMSchedulerJobCollection_basic(dataSourceName),
+					resource.TestCheckResourceAttr(dataSourceName, "subscription_id", subscriptionId),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceArmSchedulerJobCollectionRead_otherSubscription(t *testing.T) {
+	otherSubscriptionId := "11111111-1111-1111-1111-111111111111"
+
+	testCases := []struct {
+		statusCode    int
+		expectedError string
+	}{
+		{http.StatusOK, ""},
+		{http.StatusForbidden, "don't have permission"},
+	}
+
+	for _, test := range testCases {
+		var requestedPath string
+		client := &ArmClient{
+			subscriptionId:            "00000000-0000-0000-0000-000000000000",
+			resourceManagerEndpoint:   "https://management.azure.com",
+			resourceManagerAuthorizer: autorest.NullAuthorizer{},
+			StopContext:               context.Background(),
+			sender: autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+				requestedPath = r.URL.Path
+				body := fmt.Sprintf(`{"id": "/subscriptions/%s/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1", "name": "collection1", "location": "westeurope"}`, otherSubscriptionId)
+				if test.statusCode != http.StatusOK {
+					body = `{"error": {"code": "AuthorizationFailed", "message": "The client does not have authorization"}}`
+				}
+
+				return &http.Response{
+					StatusCode: test.statusCode,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       ioutil.NopCloser(strings.NewReader(body)),
+					Request:    r,
+				}, nil
+			}),
+		}
+		client.registerSchedulerClients(client.resourceManagerEndpoint, client.subscriptionId, client.resourceManagerAuthorizer)
+
+		d := schema.TestResourceDataRaw(t, dataSourceArmSchedulerJobCollection().Schema, map[string]interface{}{
+			"name":                "collection1",
+			"resource_group_name": "group1",
+			"subscription_id":     otherSubscriptionId,
+		})
+
+		err := dataSourceArmSchedulerJobCollectionRead(d, client)
+
+		if !strings.HasPrefix(requestedPath, fmt.Sprintf("/subscriptions/%s/", otherSubscriptionId)) {
+			t.Fatalf("Expected the request to be made to the other subscription but got %q", requestedPath)
+		}
+
+		if test.expectedError == "" {
+			if err != nil {
+				t.Fatalf("Expected no error for status code %d but got: %+v", test.statusCode, err)
+			}
+			if d.Get("subscription_id").(string) != otherSubscriptionId {
+				t.Fatalf("Expected the subscription_id to be %q but got %q", otherSubscriptionId, d.Get("subscription_id").(string))
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+			t.Fatalf("Expected an error containing %q for status code %d but got: %+v", test.expectedError, test.statusCode, err)
+		}
+	}
+}
+
 func testAccDataSourceSchedulerJobCollection_basic(rInt int, location string) string {
 	return fmt.Sprintf(`
 %s
@@ -48,7 +141,7 @@ data "azurerm_scheduler_job_collection" "test" {
   name                = "${azurerm_scheduler_job_collection.test.name}"
   resource_group_name = "${azurerm_resource_group.test.name}"
 }
-`, testAccAzureRMSchedulerJobCollection_basic(rInt, location, ""))
+`, testAccAzureRMSchedulerJobCollection_basic(rInt, location))
 }
 
 func testAccDataSourceSchedulerJobCollection_complete(rInt int, location string) string {
@@ -61,3 +154,15 @@ data "azurerm_scheduler_job_collection" "test" {
 }
 `, testAccAzureRMSchedulerJobCollection_complete(rInt, location))
 }
+
+func testAccDataSourceSchedulerJobCollection_subscriptionId(rInt int, location string, subscriptionId string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_scheduler_job_collection" "test" {
+  name                = "${azurerm_scheduler_job_collection.test.name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  subscription_id     = "%s"
+}
+`, testAccAzureRMSchedulerJobCollection_basic(rInt, location), subscriptionId)
+}
@@ -0,0 +1,137 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/scheduler/mgmt/2016-03-01/scheduler"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmSchedulerJobCollections() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmSchedulerJobCollectionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateSchedulerRetirement(nil),
+			},
+
+			"subscription_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateUUID,
+			},
+
+			"job_collections": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"location": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sku": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmSchedulerJobCollectionsRead(d *schema.ResourceData, meta interface{}) error {
+	ctx := meta.(*ArmClient).StopContext
+
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	//the collections can be in another subscription, which is accessed using the Provider's credentials
+	subscriptionId := d.Get("subscription_id").(string)
+	client := meta.(*ArmClient).schedulerJobCollectionsClientForSubscription(subscriptionId)
+
+	log.Printf("[DEBUG] Listing Scheduler Job Collections in Resource Group %q (Subscription %q)", resourceGroup, client.SubscriptionID)
+
+	collections := make([]scheduler.JobCollectionDefinition, 0)
+	iterator, err := client.ListByResourceGroupComplete(ctx, resourceGroup)
+	for err == nil && iterator.NotDone() {
+		collections = append(collections, iterator.Value())
+		err = iterator.Next()
+	}
+	if err != nil {
+		resp := iterator.Response().Response
+		if utils.ResponseWasNotFound(resp) {
+			return fmt.Errorf("Error: Resource Group %q was not found in Subscription %q", resourceGroup, client.SubscriptionID)
+		}
+
+		if response.WasForbidden(resp.Response) {
+			return fmt.Errorf("The credentials used by the Provider don't have permission to list the Scheduler Job Collections in Resource Group %q in Subscription %q: %s", resourceGroup, client.SubscriptionID, formatARMError(err))
+		}
+
+		if unavailableErr := schedulerServiceUnavailableError(err, ""); unavailableErr != nil {
+			return fmt.Errorf("Error listing Scheduler Job Collections in Resource Group %q: %+v", resourceGroup, unavailableErr)
+		}
+
+		return fmt.Errorf("Error listing Scheduler Job Collections in Resource Group %q: %s", resourceGroup, formatARMError(err))
+	}
+
+	d.SetId(fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Scheduler/jobCollections", client.SubscriptionID, resourceGroup))
+	d.Set("subscription_id", client.SubscriptionID)
+
+	if err := d.Set("job_collections", flattenSchedulerJobCollections(collections, client.SubscriptionID, resourceGroup)); err != nil {
+		return fmt.Errorf("Error setting `job_collections`: %+v", err)
+	}
+
+	return nil
+}
+
+// flattenSchedulerJobCollections returns the Job Collections with their canonical Resource ID, so that these can be
+// used as-is when importing them
+func flattenSchedulerJobCollections(input []scheduler.JobCollectionDefinition, subscriptionId, resourceGroup string) []interface{} {
+	results := make([]interface{}, 0)
+
+	for _, collection := range input {
+		if collection.Name == nil {
+			continue
+		}
+
+		output := map[string]interface{}{
+			"id":   schedulerJobCollectionID(subscriptionId, resourceGroup, *collection.Name),
+			"name": *collection.Name,
+		}
+
+		if location := collection.Location; location != nil {
+			output["location"] = azureRMNormalizeLocation(*location)
+		}
+
+		if properties := collection.Properties; properties != nil {
+			if sku := properties.Sku; sku != nil {
+				output["sku"] = string(sku.Name)
+			}
+			output["state"] = string(properties.State)
+		}
+
+		results = append(results, output)
+	}
+
+	return results
+}
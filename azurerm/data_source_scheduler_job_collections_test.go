@@ -0,0 +1,122 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAccDataSourceAzureRMSchedulerJobCollections_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_scheduler_job_collections.test"
+	ri := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSchedulerJobCollections_basic(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "job_collections.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "job_collections.0.id", "azurerm_scheduler_job_collection.test", "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "job_collections.0.name", "azurerm_scheduler_job_collection.test", "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "job_collections.0.location", "azurerm_scheduler_job_collection.test", "location"),
+					resource.TestCheckResourceAttr(dataSourceName, "job_collections.0.sku", "Standard"),
+					resource.TestCheckResourceAttr(dataSourceName, "job_collections.0.state", "Enabled"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceArmSchedulerJobCollectionsRead(t *testing.T) {
+	// the Job Collections are returned across two pages, with the IDs in a different casing
+	pages := []string{
+		`{"value": [{"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group1/providers/Microsoft.Scheduler/jobcollections/collection1", "name": "collection1", "location": "West Europe", "properties": {"sku": {"name": "Standard"}, "state": "Enabled"}}], "nextLink": "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections?page=2"}`,
+		`{"value": [{"name": "collection2", "location": "westeurope", "properties": {"sku": {"name": "Free"}, "state": "Suspended"}}]}`,
+	}
+
+	requests := 0
+	client := &ArmClient{
+		subscriptionId:            "00000000-0000-0000-0000-000000000000",
+		resourceManagerEndpoint:   "https://management.azure.com",
+		resourceManagerAuthorizer: autorest.NullAuthorizer{},
+		StopContext:               context.Background(),
+		sender: autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			body := pages[requests]
+			requests++
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    r,
+			}, nil
+		}),
+	}
+	client.registerSchedulerClients(client.resourceManagerEndpoint, client.subscriptionId, client.resourceManagerAuthorizer)
+
+	d := schema.TestResourceDataRaw(t, dataSourceArmSchedulerJobCollections().Schema, map[string]interface{}{
+		"resource_group_name": "group1",
+	})
+
+	if err := dataSourceArmSchedulerJobCollectionsRead(d, client); err != nil {
+		t.Fatalf("Expected no error listing the Job Collections but got: %+v", err)
+	}
+
+	if requests != len(pages) {
+		t.Fatalf("Expected %d requests but got %d", len(pages), requests)
+	}
+
+	expected := []map[string]string{
+		{
+			"id":       schedulerJobCollectionID("00000000-0000-0000-0000-000000000000", "group1", "collection1"),
+			"name":     "collection1",
+			"location": "westeurope",
+			"sku":      "Standard",
+			"state":    "Enabled",
+		},
+		{
+			"id":       schedulerJobCollectionID("00000000-0000-0000-0000-000000000000", "group1", "collection2"),
+			"name":     "collection2",
+			"location": "westeurope",
+			"sku":      "Free",
+			"state":    "Suspended",
+		},
+	}
+
+	if count := d.Get("job_collections.#").(int); count != len(expected) {
+		t.Fatalf("Expected %d Job Collections but got %d", len(expected), count)
+	}
+
+	for i, collection := range expected {
+		for key, value := range collection {
+			if actual := d.Get(fmt.Sprintf("job_collections.%d.%s", i, key)).(string); actual != value {
+				t.Fatalf("Expected `job_collections.%d.%s` to be %q but got %q", i, key, value, actual)
+			}
+		}
+
+		// the IDs must be importable as-is
+		if id, err := expandSchedulerJobCollectionImportID(collection["id"], "11111111-1111-1111-1111-111111111111"); err != nil || id != collection["id"] {
+			t.Fatalf("Expected the ID %q to be importable but got %q: %+v", collection["id"], id, err)
+		}
+	}
+}
+
+func testAccDataSourceSchedulerJobCollections_basic(rInt int, location string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_scheduler_job_collections" "test" {
+  resource_group_name = "${azurerm_scheduler_job_collection.test.resource_group_name}"
+}
+`, testAccAzureRMSchedulerJobCollection_basic(rInt, location))
+}
@@ -0,0 +1,73 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceArmSchedulerSkuQuotas() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmSchedulerSkuQuotasRead,
+
+		Schema: map[string]*schema.Schema{
+			"sku_quotas": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sku": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"max_job_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"recurrence_frequencies": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"min_recurrence_interval_in_minutes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmSchedulerSkuQuotasRead(d *schema.ResourceData, meta interface{}) error {
+	// these are the documented limits for each SKU, rather than being retrieved from the API
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("sku_quotas", flattenSchedulerSkuQuotas(schedulerSkuQuotas)); err != nil {
+		return fmt.Errorf("Error setting `sku_quotas`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenSchedulerSkuQuotas(input []schedulerSkuQuota) []interface{} {
+	results := make([]interface{}, 0)
+
+	for _, quota := range input {
+		frequencies := make([]interface{}, 0)
+		for _, frequency := range quota.recurrenceFrequencies {
+			frequencies = append(frequencies, frequency)
+		}
+
+		results = append(results, map[string]interface{}{
+			"sku":                                quota.sku,
+			"max_job_count":                      quota.maxJobCount,
+			"recurrence_frequencies":             frequencies,
+			"min_recurrence_interval_in_minutes": quota.minRecurrenceIntervalInMinutes,
+		})
+	}
+
+	return results
+}
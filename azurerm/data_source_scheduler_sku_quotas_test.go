@@ -0,0 +1,80 @@
+package azurerm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestSchedulerSkuQuotaForSku(t *testing.T) {
+	testCases := []struct {
+		sku         string
+		exists      bool
+		maxJobCount int
+	}{
+		{"Free", true, 5},
+		{"free", true, 5},
+		{"Standard", true, 50},
+		{"P10Premium", true, 50},
+		{"p20premium", true, 1000},
+		{"Basic", false, 0},
+	}
+
+	for _, test := range testCases {
+		quota, exists := schedulerSkuQuotaForSku(test.sku)
+
+		if exists != test.exists {
+			t.Fatalf("Expected SKU %q to exist: %t but got %t", test.sku, test.exists, exists)
+		}
+
+		if quota.maxJobCount != test.maxJobCount {
+			t.Fatalf("Expected SKU %q to have a max job count of %d but got %d", test.sku, test.maxJobCount, quota.maxJobCount)
+		}
+	}
+}
+
+func TestFlattenSchedulerSkuQuotas(t *testing.T) {
+	results := flattenSchedulerSkuQuotas(schedulerSkuQuotas)
+
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 SKU quotas but got %d", len(results))
+	}
+
+	free := results[0].(map[string]interface{})
+	if free["sku"] != "Free" {
+		t.Fatalf("Expected the first SKU to be %q but got %q", "Free", free["sku"])
+	}
+
+	if v := free["min_recurrence_interval_in_minutes"].(int); v != 60 {
+		t.Fatalf("Expected the Free SKU to have a minimum recurrence interval of 60 minutes but got %d", v)
+	}
+
+	if v := len(free["recurrence_frequencies"].([]interface{})); v != 4 {
+		t.Fatalf("Expected the Free SKU to support 4 recurrence frequencies but got %d", v)
+	}
+}
+
+func TestAccDataSourceAzureRMSchedulerSkuQuotas_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_scheduler_sku_quotas.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSchedulerSkuQuotas_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "sku_quotas.#", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "sku_quotas.0.sku", "Free"),
+					resource.TestCheckResourceAttr(dataSourceName, "sku_quotas.0.max_job_count", "5"),
+					resource.TestCheckResourceAttr(dataSourceName, "sku_quotas.3.sku", "P20Premium"),
+					resource.TestCheckResourceAttr(dataSourceName, "sku_quotas.3.recurrence_frequencies.#", "5"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSchedulerSkuQuotas_basic = `
+data "azurerm_scheduler_sku_quotas" "test" {}
+`
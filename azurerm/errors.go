@@ -0,0 +1,258 @@
+package azurerm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// formatARMError prefixes the error with the ARM error `code` and `message` (when they're available)
+// in a structured format (e.g. `code=ResourceNotFound message="..."`) so that users can match on them.
+//
+// The full error response is also logged at the DEBUG level, since the formatted error omits any nested details
+// (such as `additionalInfo`) which can be needed to diagnose the failure.
+func formatARMError(err error) string {
+	logARMErrorResponse(err)
+
+	serviceError := armServiceError(err)
+	if serviceError == nil || serviceError.Code == "" {
+		return fmt.Sprintf("%+v", err)
+	}
+
+	return fmt.Sprintf("code=%s message=%q: %+v", serviceError.Code, serviceError.Message, err)
+}
+
+// armErrorResponseBody is the body of an error response buffered by withBufferedErrorResponses, which can be read
+// again once the SDK has consumed it
+type armErrorResponseBody struct {
+	*bytes.Reader
+	raw []byte
+}
+
+func (b *armErrorResponseBody) Close() error {
+	return nil
+}
+
+// armServiceError returns the error returned from the Azure Resource Manager API, if any,
+// unwrapping the errors returned from the SDK as needed
+func armServiceError(err error) *azure.ServiceError {
+	if serviceError := armServiceErrorFromSDK(err); serviceError != nil {
+		return serviceError
+	}
+
+	// the SDK returns some error responses as a plain error, in which case this is parsed from the buffered body
+	if resp := armErrorResponse(err); resp != nil {
+		if body, ok := resp.Body.(*armErrorResponseBody); ok {
+			var requestError azure.RequestError
+			if json.Unmarshal(body.raw, &requestError) == nil {
+				return requestError.ServiceError
+			}
+		}
+	}
+
+	return nil
+}
+
+func armServiceErrorFromSDK(err error) *azure.ServiceError {
+	for err != nil {
+		switch e := err.(type) {
+		case autorest.DetailedError:
+			err = e.Original
+		case *autorest.DetailedError:
+			err = e.Original
+		case azure.RequestError:
+			return e.ServiceError
+		case *azure.RequestError:
+			return e.ServiceError
+		case azure.ServiceError:
+			return &e
+		case *azure.ServiceError:
+			return e
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// armErrorResponse returns the HTTP response which the error was returned for, if any, unwrapping the errors
+// returned from the SDK as needed
+func armErrorResponse(err error) *http.Response {
+	for err != nil {
+		switch e := err.(type) {
+		case autorest.DetailedError:
+			if e.Response != nil {
+				return e.Response
+			}
+			err = e.Original
+		case *autorest.DetailedError:
+			if e.Response != nil {
+				return e.Response
+			}
+			err = e.Original
+		case azure.RequestError:
+			return e.Response
+		case *azure.RequestError:
+			return e.Response
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// armRetriableStatusCodes are the status codes returned when a request fails temporarily - such as when it's been
+// throttled (429) or the service is unavailable (503) - and so is worth retrying
+var armRetriableStatusCodes = []int{
+	http.StatusRequestTimeout,
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// armErrorIsRetriable returns whether the request which returned the error failed temporarily, in which case it's
+// worth retrying - otherwise the error is permanent (e.g. a 400, 403 or 404) and retrying won't help.
+func armErrorIsRetriable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if resp := armErrorResponse(err); resp != nil {
+		for _, statusCode := range armRetriableStatusCodes {
+			if resp.StatusCode == statusCode {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return armConnectionErrorIsTransient(err)
+}
+
+// armConnectionErrorIsTransient returns whether the request failed without a response being returned since the
+// connection was reset or timed out, unwrapping the errors returned from the SDK as needed
+func armConnectionErrorIsTransient(err error) bool {
+	for err != nil {
+		switch e := err.(type) {
+		case autorest.DetailedError:
+			err = e.Original
+		case *autorest.DetailedError:
+			err = e.Original
+		case *url.Error:
+			if e.Timeout() {
+				return true
+			}
+			err = e.Err
+		case *net.OpError:
+			if e.Timeout() {
+				return true
+			}
+			err = e.Err
+		case *os.SyscallError:
+			err = e.Err
+		case syscall.Errno:
+			return e == syscall.ECONNRESET || e == syscall.ECONNABORTED
+		default:
+			// the connection was closed before the response was returned
+			return err == io.EOF || err == io.ErrUnexpectedEOF
+		}
+	}
+
+	return false
+}
+
+// armSensitiveHeaders are the headers whose values are redacted when logging an error response
+var armSensitiveHeaders = []string{
+	"Authorization",
+	"X-Ms-Authorization-Auxiliary",
+	"Ocp-Apim-Subscription-Key",
+}
+
+// armSASTokenRegex matches the signature of a SAS token (e.g. `sig=...`) and the keys within a connection string
+var armSASTokenRegex = regexp.MustCompile(`(?i)\b(sig|SharedAccessKey|AccountKey)=[^&;"'\s]+`)
+
+// logARMErrorResponse logs the full error response (including the request which was made) at the DEBUG level, with the
+// credentials and any SAS tokens redacted
+func logARMErrorResponse(err error) {
+	resp := armErrorResponse(err)
+	if resp == nil {
+		return
+	}
+
+	body := ""
+	if b, ok := resp.Body.(*armErrorResponseBody); ok {
+		body = string(b.raw)
+	} else if resp.Body != nil {
+		b, readErr := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		// replace the body, since it may be read again later
+		resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+		if readErr == nil {
+			body = string(b)
+		}
+	}
+
+	request := ""
+	requestHeaders := ""
+	if req := resp.Request; req != nil {
+		if req.URL != nil {
+			request = fmt.Sprintf("%s %s", req.Method, redactSASTokens(req.URL.String()))
+		}
+		requestHeaders = formatRedactedHeaders(req.Header)
+	}
+
+	log.Printf("[DEBUG] Azure returned an error response (status %d) for the request %q:\nRequest Headers: %s\nResponse Headers: %s\nResponse Body: %s",
+		resp.StatusCode, request, requestHeaders, formatRedactedHeaders(resp.Header), redactSASTokens(body))
+}
+
+// formatRedactedHeaders formats the headers in a consistent order, with the values of any sensitive headers redacted
+func formatRedactedHeaders(headers http.Header) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	formatted := make([]string, 0, len(names))
+	for _, name := range names {
+		value := redactSASTokens(strings.Join(headers[name], ", "))
+		for _, sensitive := range armSensitiveHeaders {
+			if strings.EqualFold(name, sensitive) {
+				value = "REDACTED"
+				break
+			}
+		}
+
+		formatted = append(formatted, fmt.Sprintf("%s: %s", name, value))
+	}
+
+	return strings.Join(formatted, "; ")
+}
+
+func redactSASTokens(input string) string {
+	return armSASTokenRegex.ReplaceAllString(input, "${1}=REDACTED")
+}
+
+// importAsExistsError is returned when creating a resource which already exists in Azure and `requires_import`
+// is enabled, rather than adopting (and potentially overwriting) the existing resource
+func importAsExistsError(resourceName string, id string) error {
+	return fmt.Errorf("A resource with the ID %q already exists - to be managed via Terraform this resource needs to be imported into the State. Please see the resource documentation for %q for more information.", id, resourceName)
+}
@@ -0,0 +1,241 @@
+package azurerm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+func TestFormatARMError(t *testing.T) {
+	serviceError := &azure.ServiceError{
+		Code:    "ResourceNotFound",
+		Message: "The Resource was not found.",
+	}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "plain error",
+			err:      errors.New("boom"),
+			expected: "boom",
+		},
+		{
+			name:     "detailed error without a service error",
+			err:      autorest.DetailedError{Original: errors.New("boom")},
+			expected: autorest.DetailedError{Original: errors.New("boom")}.Error(),
+		},
+		{
+			name:     "request error",
+			err:      &azure.RequestError{ServiceError: serviceError},
+			expected: `code=ResourceNotFound message="The Resource was not found.": ` + (&azure.RequestError{ServiceError: serviceError}).Error(),
+		},
+		{
+			name:     "request error wrapped in a detailed error",
+			err:      autorest.DetailedError{Original: &azure.RequestError{ServiceError: serviceError}},
+			expected: `code=ResourceNotFound message="The Resource was not found.": ` + autorest.DetailedError{Original: &azure.RequestError{ServiceError: serviceError}}.Error(),
+		},
+		{
+			name:     "service error wrapped in a detailed error",
+			err:      autorest.DetailedError{Original: *serviceError},
+			expected: `code=ResourceNotFound message="The Resource was not found.": ` + autorest.DetailedError{Original: *serviceError}.Error(),
+		},
+		{
+			name:     "request error without a code",
+			err:      &azure.RequestError{ServiceError: &azure.ServiceError{}},
+			expected: (&azure.RequestError{ServiceError: &azure.ServiceError{}}).Error(),
+		},
+	}
+
+	for _, test := range testCases {
+		actual := formatARMError(test.err)
+		if actual != test.expected {
+			t.Fatalf("Expected the %s to be formatted as %q but got %q", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestImportAsExistsError(t *testing.T) {
+	id := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1"
+	err := importAsExistsError("azurerm_scheduler_job_collection", id)
+
+	if !testRequiresImportError("azurerm_scheduler_job_collection").MatchString(err.Error()) {
+		t.Fatalf("Expected the error to ask for %q to be imported but got %q", "azurerm_scheduler_job_collection", err.Error())
+	}
+
+	if !strings.Contains(err.Error(), id) {
+		t.Fatalf("Expected the error to contain the ID %q but got %q", id, err.Error())
+	}
+}
+
+func TestRedactSASTokens(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "https://example.blob.core.windows.net/logs/file.log",
+			expected: "https://example.blob.core.windows.net/logs/file.log",
+		},
+		{
+			input:    "https://example.blob.core.windows.net/logs/file.log?sv=2017-04-17&sig=abc%2Fdef%3D&se=2018-01-01",
+			expected: "https://example.blob.core.windows.net/logs/file.log?sv=2017-04-17&sig=REDACTED&se=2018-01-01",
+		},
+		{
+			input:    `{"url": "https://example/file.log?sig=abc123"}`,
+			expected: `{"url": "https://example/file.log?sig=REDACTED"}`,
+		},
+		{
+			input:    "Endpoint=sb://example.servicebus.windows.net/;SharedAccessKeyName=root;SharedAccessKey=abc123=",
+			expected: "Endpoint=sb://example.servicebus.windows.net/;SharedAccessKeyName=root;SharedAccessKey=REDACTED",
+		},
+		{
+			input:    "DefaultEndpointsProtocol=https;AccountName=example;AccountKey=abc123==;EndpointSuffix=core.windows.net",
+			expected: "DefaultEndpointsProtocol=https;AccountName=example;AccountKey=REDACTED;EndpointSuffix=core.windows.net",
+		},
+	}
+
+	for _, test := range testCases {
+		actual := redactSASTokens(test.input)
+		if actual != test.expected {
+			t.Fatalf("Expected %q to be redacted as %q but got %q", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestFormatRedactedHeaders(t *testing.T) {
+	headers := http.Header{
+		"Authorization":                []string{"Bearer eyJ0eXAi"},
+		"X-Ms-Authorization-Auxiliary": []string{"Bearer eyJ0eXAi"},
+		"X-Ms-Request-Id":              []string{"00000000-0000-0000-0000-000000000000"},
+		"Location":                     []string{"https://example/file.log?sig=abc123"},
+	}
+
+	expected := "Authorization: REDACTED; Location: https://example/file.log?sig=REDACTED; X-Ms-Authorization-Auxiliary: REDACTED; X-Ms-Request-Id: 00000000-0000-0000-0000-000000000000"
+	if actual := formatRedactedHeaders(headers); actual != expected {
+		t.Fatalf("Expected the headers to be formatted as %q but got %q", expected, actual)
+	}
+}
+
+func TestLogARMErrorResponse(t *testing.T) {
+	body := `{"error": {"code": "BadRequest", "message": "The request was invalid.", "additionalInfo": [{"type": "PolicyViolation", "info": {"policyDefinitionName": "require-tags"}}]}}`
+
+	req, err := http.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000?sig=abc123", nil)
+	if err != nil {
+		t.Fatalf("Error building the request: %+v", err)
+	}
+	req.Header.Set("Authorization", "Bearer eyJ0eXAi")
+
+	resp := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Header:     http.Header{"X-Ms-Request-Id": []string{"00000000-0000-0000-0000-000000000000"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	formatARMError(autorest.NewErrorWithError(errors.New("boom"), "scheduler.JobCollectionsClient", "CreateOrUpdate", resp, "Failure responding to request"))
+
+	logged := buf.String()
+	if !strings.Contains(logged, `"additionalInfo"`) {
+		t.Fatalf("Expected the full response body to be logged but got: %s", logged)
+	}
+
+	if strings.Contains(logged, "eyJ0eXAi") || strings.Contains(logged, "abc123") {
+		t.Fatalf("Expected the credentials and SAS tokens to be redacted but got: %s", logged)
+	}
+
+	// the response body should still be readable afterwards
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Error reading the response body: %+v", err)
+	}
+	if string(b) != body {
+		t.Fatalf("Expected the response body to still be readable but got %q", string(b))
+	}
+}
+
+func TestLogARMErrorResponse_noResponse(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	formatARMError(errors.New("boom"))
+
+	if buf.Len() != 0 {
+		t.Fatalf("Expected nothing to be logged when there's no response but got: %s", buf.String())
+	}
+}
+
+// testTimeoutError is returned when a connection times out
+type testTimeoutError struct{}
+
+func (testTimeoutError) Error() string   { return "i/o timeout" }
+func (testTimeoutError) Timeout() bool   { return true }
+func (testTimeoutError) Temporary() bool { return true }
+
+func TestArmErrorIsRetriable(t *testing.T) {
+	responseError := func(statusCode int) error {
+		resp := &http.Response{StatusCode: statusCode}
+		return autorest.NewErrorWithError(errors.New("request failed"), "scheduler.JobCollectionsClient", "Get", resp, "Failure responding to request")
+	}
+
+	serviceError := func(statusCode int, code string) error {
+		return autorest.DetailedError{
+			Original: &azure.RequestError{
+				DetailedError: autorest.DetailedError{StatusCode: statusCode},
+				ServiceError:  &azure.ServiceError{Code: code},
+			},
+			Response: &http.Response{StatusCode: statusCode},
+		}
+	}
+
+	connectionError := func(err error) error {
+		return autorest.NewErrorWithError(&url.Error{Op: "Get", URL: "https://management.azure.com", Err: err}, "scheduler.JobCollectionsClient", "Get", nil, "Failure sending request")
+	}
+
+	testCases := []struct {
+		name      string
+		err       error
+		retriable bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("boom"), false},
+		{"throttled", responseError(http.StatusTooManyRequests), true},
+		{"service unavailable", responseError(http.StatusServiceUnavailable), true},
+		{"gateway timeout", responseError(http.StatusGatewayTimeout), true},
+		{"throttled service error", serviceError(http.StatusTooManyRequests, "TooManyRequests"), true},
+		{"bad request", responseError(http.StatusBadRequest), false},
+		{"forbidden", serviceError(http.StatusForbidden, "AuthorizationFailed"), false},
+		{"not found", responseError(http.StatusNotFound), false},
+		{"conflict", serviceError(http.StatusConflict, "Conflict"), false},
+		{"connection reset", connectionError(&net.OpError{Op: "read", Net: "tcp", Err: &os.SyscallError{Syscall: "read", Err: syscall.ECONNRESET}}), true},
+		{"connection timed out", connectionError(&net.OpError{Op: "dial", Net: "tcp", Err: testTimeoutError{}}), true},
+		{"connection closed", connectionError(io.EOF), true},
+		{"connection refused", connectionError(&net.OpError{Op: "dial", Net: "tcp", Err: &os.SyscallError{Syscall: "connect", Err: syscall.ECONNREFUSED}}), false},
+		{"unknown host", connectionError(&net.DNSError{Err: "no such host", Name: "management.azure.com"}), false},
+	}
+
+	for _, test := range testCases {
+		if retriable := armErrorIsRetriable(test.err); retriable != test.retriable {
+			t.Fatalf("Expected the %q error to be retriable: %t but got %t", test.name, test.retriable, retriable)
+		}
+	}
+}
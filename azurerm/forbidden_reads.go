@@ -0,0 +1,20 @@
+package azurerm
+
+import (
+	"log"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// readWasForbidden returns whether a Read which failed since access to the resource was forbidden should instead leave
+// the resource in the state unchanged - for example where an Azure Policy revokes read access after the resource has
+// been created. This is only the case when the `ignore_forbidden_reads` Provider option is enabled.
+func readWasForbidden(client *ArmClient, resp autorest.Response, description string) bool {
+	if !client.ignoreForbiddenReads || !utils.ResponseWasForbidden(resp) {
+		return false
+	}
+
+	log.Printf("[WARN] Access to %s was forbidden (403) - since `ignore_forbidden_reads` is enabled it can't be refreshed, so is assumed to be unchanged", description)
+	return true
+}
@@ -0,0 +1,44 @@
+package azurerm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestReadWasForbidden(t *testing.T) {
+	testCases := []struct {
+		ignoreForbiddenReads bool
+		statusCode           int
+		expected             bool
+	}{
+		{false, http.StatusForbidden, false},
+		{false, http.StatusNotFound, false},
+		{true, http.StatusForbidden, true},
+		{true, http.StatusNotFound, false},
+		{true, http.StatusUnauthorized, false},
+		{true, http.StatusInternalServerError, false},
+	}
+
+	for _, test := range testCases {
+		client := &ArmClient{
+			ignoreForbiddenReads: test.ignoreForbiddenReads,
+		}
+		resp := autorest.Response{
+			Response: &http.Response{
+				StatusCode: test.statusCode,
+			},
+		}
+
+		actual := readWasForbidden(client, resp, "the resource")
+		if actual != test.expected {
+			t.Fatalf("Expected %t for a %d when `ignore_forbidden_reads` is %t but got %t", test.expected, test.statusCode, test.ignoreForbiddenReads, actual)
+		}
+	}
+
+	// the request may have failed before a response was received
+	if readWasForbidden(&ArmClient{ignoreForbiddenReads: true}, autorest.Response{}, "the resource") {
+		t.Fatalf("Expected false when there's no response")
+	}
+}
@@ -0,0 +1,120 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// transientErrorDefaultRetryAfter is used when a request which failed temporarily doesn't include a `Retry-After` header
+const transientErrorDefaultRetryAfter = 15 * time.Second
+
+// futureWaiter is implemented by the Futures returned from the SDK for long-running operations
+type futureWaiter interface {
+	WaitForCompletion(ctx context.Context, client autorest.Client) error
+}
+
+// waitForCompletionRetryingOnTransientErrors waits for the long-running operation to complete. Should polling
+// the operation fail temporarily (e.g. it's throttled, the service is unavailable or the connection is reset - see
+// `armErrorIsRetriable`) the operation is polled again once the `Retry-After` interval has elapsed, until `timeout`
+// has been reached - any other error is returned straight away. Once finished a summary of the wait is logged,
+// which can be used to tune the timeouts.
+func waitForCompletionRetryingOnTransientErrors(ctx context.Context, description string, future futureWaiter, client autorest.Client, timeout time.Duration) (err error) {
+	stats := longRunningOperationStats{
+		description: description,
+		start:       time.Now(),
+	}
+	client.Sender = stats.countRequests(client.Sender)
+	defer func() {
+		stats.log(err)
+	}()
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		err := future.WaitForCompletion(ctx, client)
+		if err == nil {
+			return nil
+		}
+
+		if !armErrorIsRetriable(err) {
+			return err
+		}
+
+		delay := retryAfterFromResponse(armErrorResponse(err))
+		if time.Now().Add(delay).After(deadline) {
+			return fmt.Errorf("Still failing temporarily after %s: %+v", timeout, err)
+		}
+
+		stats.retried++
+		log.Printf("[DEBUG] Failed temporarily whilst waiting for the operation to complete - polling again in %s: %+v", delay, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Context was cancelled whilst retrying: %+v", err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+// longRunningOperationStats tracks the requests made whilst waiting for a long-running operation
+type longRunningOperationStats struct {
+	description string
+	start       time.Time
+	polls       int
+	retried     int
+}
+
+// countRequests wraps the Sender so that each request polling the operation is counted
+func (s *longRunningOperationStats) countRequests(sender autorest.Sender) autorest.Sender {
+	if sender == nil {
+		sender = &http.Client{}
+	}
+
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		s.polls++
+		return sender.Do(r)
+	})
+}
+
+func (s *longRunningOperationStats) log(err error) {
+	result := "completed"
+	if err != nil {
+		result = "failed"
+	}
+
+	log.Printf("[DEBUG] Waiting for %s %s after %d polls (%d retried) in %s", s.description, result, s.polls, s.retried, time.Since(s.start))
+}
+
+func retryAfterFromResponse(resp *http.Response) time.Duration {
+	if resp == nil {
+		return transientErrorDefaultRetryAfter
+	}
+
+	seconds, err := strconv.Atoi(resp.Header.Get(autorest.HeaderRetryAfter))
+	if err != nil || seconds < 0 {
+		return transientErrorDefaultRetryAfter
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// waitForDelay waits for the specified delay before returning - or returns an error should the context be
+// cancelled whilst waiting
+func waitForDelay(ctx context.Context, description string, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Waiting %s for %s", delay, description)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("Context was cancelled whilst waiting %s for %s", delay, description)
+	case <-time.After(delay):
+		return nil
+	}
+}
@@ -0,0 +1,255 @@
+package azurerm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+type testFuture struct {
+	responses []*http.Response
+	calls     int
+}
+
+func (f *testFuture) WaitForCompletion(ctx context.Context, client autorest.Client) error {
+	resp := f.responses[f.calls]
+	f.calls++
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return autorest.NewErrorWithError(fmt.Errorf("unexpected status %d", resp.StatusCode), "test", "WaitForCompletion", resp, "polling failed")
+}
+
+func testFutureResponse(statusCode int, retryAfter string) *http.Response {
+	resp := &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+	}
+	if retryAfter != "" {
+		resp.Header.Set(autorest.HeaderRetryAfter, retryAfter)
+	}
+	return resp
+}
+
+func TestWaitForCompletionRetryingOnTransientErrors_ThrottledThenSuccess(t *testing.T) {
+	future := &testFuture{
+		responses: []*http.Response{
+			testFutureResponse(http.StatusTooManyRequests, "0"),
+			testFutureResponse(http.StatusOK, ""),
+		},
+	}
+
+	err := waitForCompletionRetryingOnTransientErrors(context.Background(), "the operation", future, autorest.Client{}, time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	if future.calls != 2 {
+		t.Fatalf("Expected the operation to be polled 2 times but was polled %d times", future.calls)
+	}
+}
+
+func TestWaitForCompletionRetryingOnTransientErrors_UnavailableThenSuccess(t *testing.T) {
+	future := &testFuture{
+		responses: []*http.Response{
+			testFutureResponse(http.StatusServiceUnavailable, "0"),
+			testFutureResponse(http.StatusOK, ""),
+		},
+	}
+
+	err := waitForCompletionRetryingOnTransientErrors(context.Background(), "the operation", future, autorest.Client{}, time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	if future.calls != 2 {
+		t.Fatalf("Expected the operation to be polled 2 times but was polled %d times", future.calls)
+	}
+}
+
+// testErrorFuture returns each of the errors in turn when waiting for completion, completing once there are none left
+type testErrorFuture struct {
+	errs  []error
+	calls int
+}
+
+func (f *testErrorFuture) WaitForCompletion(ctx context.Context, client autorest.Client) error {
+	f.calls++
+	if len(f.errs) == 0 {
+		return nil
+	}
+
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+func TestWaitForCompletionRetryingOnTransientErrors_ConnectionReset(t *testing.T) {
+	reset := &url.Error{Op: "Get", URL: "https://management.azure.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: &os.SyscallError{Syscall: "read", Err: syscall.ECONNRESET}}}
+	future := &testErrorFuture{
+		errs: []error{autorest.NewErrorWithError(reset, "test", "WaitForCompletion", nil, "polling failed")},
+	}
+
+	// there's no response to read the `Retry-After` from, so this waits for the default interval
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := waitForCompletionRetryingOnTransientErrors(ctx, "the operation", future, autorest.Client{}, time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "cancelled whilst retrying") {
+		t.Fatalf("Expected the connection reset to be retried until the context was cancelled but got: %+v", err)
+	}
+}
+
+func TestWaitForCompletionRetryingOnTransientErrors_NotFound(t *testing.T) {
+	future := &testFuture{
+		responses: []*http.Response{
+			testFutureResponse(http.StatusNotFound, "0"),
+			testFutureResponse(http.StatusOK, ""),
+		},
+	}
+
+	err := waitForCompletionRetryingOnTransientErrors(context.Background(), "the operation", future, autorest.Client{}, time.Minute)
+	if err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+
+	if future.calls != 1 {
+		t.Fatalf("Expected the operation to be polled once but was polled %d times", future.calls)
+	}
+}
+
+func TestWaitForCompletionRetryingOnTransientErrors_OtherError(t *testing.T) {
+	future := &testFuture{
+		responses: []*http.Response{
+			testFutureResponse(http.StatusBadRequest, ""),
+			testFutureResponse(http.StatusOK, ""),
+		},
+	}
+
+	err := waitForCompletionRetryingOnTransientErrors(context.Background(), "the operation", future, autorest.Client{}, time.Minute)
+	if err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+
+	if future.calls != 1 {
+		t.Fatalf("Expected the operation to be polled once but was polled %d times", future.calls)
+	}
+}
+
+func TestWaitForCompletionRetryingOnTransientErrors_TimeoutExceeded(t *testing.T) {
+	future := &testFuture{
+		responses: []*http.Response{
+			testFutureResponse(http.StatusTooManyRequests, "120"),
+			testFutureResponse(http.StatusOK, ""),
+		},
+	}
+
+	err := waitForCompletionRetryingOnTransientErrors(context.Background(), "the operation", future, autorest.Client{}, time.Minute)
+	if err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+
+	if future.calls != 1 {
+		t.Fatalf("Expected the operation to be polled once but was polled %d times", future.calls)
+	}
+}
+
+// testPollingFuture polls the operation via the client the given number of times before completing
+type testPollingFuture struct {
+	polls int
+}
+
+func (f *testPollingFuture) WaitForCompletion(ctx context.Context, client autorest.Client) error {
+	for i := 0; i < f.polls; i++ {
+		req, err := http.NewRequest(http.MethodGet, "https://management.azure.com/operations/example", nil)
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.Do(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestWaitForCompletionRetryingOnTransientErrors_LogsSummary(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	client := autorest.Client{
+		Sender: autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			return testFutureResponse(http.StatusOK, ""), nil
+		}),
+	}
+
+	future := &testPollingFuture{polls: 3}
+	if err := waitForCompletionRetryingOnTransientErrors(context.Background(), "the operation", future, client, time.Minute); err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	expected := "Waiting for the operation completed after 3 polls (0 retried)"
+	if !strings.Contains(buf.String(), expected) {
+		t.Fatalf("Expected the log output to contain %q but got %q", expected, buf.String())
+	}
+}
+
+func TestRetryAfterFromResponse(t *testing.T) {
+	testCases := []struct {
+		retryAfter string
+		expected   time.Duration
+	}{
+		{"", transientErrorDefaultRetryAfter},
+		{"abc", transientErrorDefaultRetryAfter},
+		{"-1", transientErrorDefaultRetryAfter},
+		{"0", 0},
+		{"30", 30 * time.Second},
+	}
+
+	for _, test := range testCases {
+		actual := retryAfterFromResponse(testFutureResponse(http.StatusTooManyRequests, test.retryAfter))
+		if actual != test.expected {
+			t.Fatalf("Expected %q to be %s but got %s", test.retryAfter, test.expected, actual)
+		}
+	}
+}
+
+func TestWaitForDelay(t *testing.T) {
+	if err := waitForDelay(context.Background(), "nothing", 0); err != nil {
+		t.Fatalf("Expected no error for a zero delay but got: %+v", err)
+	}
+
+	start := time.Now()
+	if err := waitForDelay(context.Background(), "a short delay", 10*time.Millisecond); err != nil {
+		t.Fatalf("Expected no error for a short delay but got: %+v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Fatalf("Expected to wait at least 10ms but only waited %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start = time.Now()
+	if err := waitForDelay(ctx, "a cancelled delay", time.Hour); err == nil {
+		t.Fatalf("Expected an error when the context is cancelled but didn't get one")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Expected to return as soon as the context was cancelled but waited %s", elapsed)
+	}
+}
@@ -8,10 +8,22 @@ func WasConflict(resp *http.Response) bool {
 	return responseWasStatusCode(resp, http.StatusConflict)
 }
 
+func WasForbidden(resp *http.Response) bool {
+	return responseWasStatusCode(resp, http.StatusForbidden)
+}
+
 func WasNotFound(resp *http.Response) bool {
 	return responseWasStatusCode(resp, http.StatusNotFound)
 }
 
+func WasPreconditionFailed(resp *http.Response) bool {
+	return responseWasStatusCode(resp, http.StatusPreconditionFailed)
+}
+
+func WasThrottled(resp *http.Response) bool {
+	return responseWasStatusCode(resp, http.StatusTooManyRequests)
+}
+
 func responseWasStatusCode(resp *http.Response, statusCode int) bool {
 	if r := resp; r != nil {
 		if r.StatusCode == statusCode {
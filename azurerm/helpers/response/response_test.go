@@ -35,6 +35,36 @@ func TestConflcit_StatusCodes(t *testing.T) {
 	}
 }
 
+func TestForbidden_DroppedConnection(t *testing.T) {
+	resp := http.Response{}
+	if WasForbidden(&resp) {
+		t.Fatalf("wasForbidden should return `false` for a dropped connection")
+	}
+}
+
+func TestForbidden_StatusCodes(t *testing.T) {
+	testCases := []struct {
+		statusCode     int
+		expectedResult bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusUnauthorized, false},
+		{http.StatusNotFound, false},
+		{http.StatusForbidden, true},
+	}
+
+	for _, test := range testCases {
+		resp := http.Response{
+			StatusCode: test.statusCode,
+		}
+		result := WasForbidden(&resp)
+		if test.expectedResult != result {
+			t.Fatalf("Expected '%+v' for status code '%d' - got '%+v'",
+				test.expectedResult, test.statusCode, result)
+		}
+	}
+}
+
 func TestNotFound_DroppedConnection(t *testing.T) {
 	resp := http.Response{}
 	if WasNotFound(&resp) {
@@ -63,3 +93,63 @@ func TestNotFound_StatusCodes(t *testing.T) {
 		}
 	}
 }
+
+func TestPreconditionFailed_DroppedConnection(t *testing.T) {
+	resp := http.Response{}
+	if WasPreconditionFailed(&resp) {
+		t.Fatalf("wasPreconditionFailed should return `false` for a dropped connection")
+	}
+}
+
+func TestPreconditionFailed_StatusCodes(t *testing.T) {
+	testCases := []struct {
+		statusCode     int
+		expectedResult bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusConflict, false},
+		{http.StatusNotFound, false},
+		{http.StatusPreconditionFailed, true},
+	}
+
+	for _, test := range testCases {
+		resp := http.Response{
+			StatusCode: test.statusCode,
+		}
+		result := WasPreconditionFailed(&resp)
+		if test.expectedResult != result {
+			t.Fatalf("Expected '%+v' for status code '%d' - got '%+v'",
+				test.expectedResult, test.statusCode, result)
+		}
+	}
+}
+
+func TestThrottled_DroppedConnection(t *testing.T) {
+	resp := http.Response{}
+	if WasThrottled(&resp) {
+		t.Fatalf("wasThrottled should return `false` for a dropped connection")
+	}
+}
+
+func TestThrottled_StatusCodes(t *testing.T) {
+	testCases := []struct {
+		statusCode     int
+		expectedResult bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusServiceUnavailable, false},
+		{http.StatusNotFound, false},
+		{http.StatusTooManyRequests, true},
+	}
+
+	for _, test := range testCases {
+		resp := http.Response{
+			StatusCode: test.statusCode,
+		}
+		result := WasThrottled(&resp)
+		if test.expectedResult != result {
+			t.Fatalf("Expected '%+v' for status code '%d' - got '%+v'",
+				test.expectedResult, test.statusCode, result)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package azurerm
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMMySQLConfiguration_importCharacterSetServer(t *testing.T) {
@@ -30,6 +32,47 @@ func TestAccAzureRMMySQLConfiguration_importCharacterSetServer(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMMySQLConfiguration_importThenDestroyResetsValue(t *testing.T) {
+	resourceName := "azurerm_mysql_configuration.test"
+
+	ri := acctest.RandInt()
+	location := testLocation()
+	config := testAccAzureRMMySQLConfiguration_interactiveTimeout(ri, location)
+	serverOnlyConfig := testAccAzureRMMySQLConfiguration_empty(ri, location)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMySQLConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				// the imported state must include the default value, so the destroy below can reset to it
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					for _, state := range states {
+						if state.Attributes["default_value"] == "" {
+							return fmt.Errorf("Expected `default_value` to be set on import")
+						}
+					}
+					return nil
+				},
+			},
+			{
+				Config: serverOnlyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					// "delete" resets back to the default value, rather than leaving the imported value
+					testCheckAzureRMMySQLConfigurationValueReset(ri, "interactive_timeout"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMPostgreSQLConfiguration_importInteractiveTimeout(t *testing.T) {
 	resourceName := "azurerm_mysql_configuration.test"
 
@@ -0,0 +1,98 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/scheduler/mgmt/2016-03-01/scheduler"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAzureRMSchedulerJobCollection_importEnabled(t *testing.T) {
+	testAccAzureRMSchedulerJobCollection_importState(t, scheduler.Enabled)
+}
+
+func TestAccAzureRMSchedulerJobCollection_importDisabled(t *testing.T) {
+	testAccAzureRMSchedulerJobCollection_importState(t, scheduler.Disabled)
+}
+
+func TestAccAzureRMSchedulerJobCollection_importSuspended(t *testing.T) {
+	testAccAzureRMSchedulerJobCollection_importState(t, scheduler.Suspended)
+}
+
+func testAccAzureRMSchedulerJobCollection_importState(t *testing.T, state scheduler.JobCollectionState) {
+	resourceName := "azurerm_scheduler_job_collection.test"
+
+	ri := acctest.RandInt()
+	config := testAccAzureRMSchedulerJobCollection_template(ri, testLocation(), fmt.Sprintf("  state = %q", string(state)))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMSchedulerJobCollection_importShorthand(t *testing.T) {
+	resourceName := "azurerm_scheduler_job_collection.test"
+
+	ri := acctest.RandInt()
+	config := testAccAzureRMSchedulerJobCollection_basic(ri, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("acctestRG-%d/acctest-%d", ri, ri),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMSchedulerJobCollection_importComplete(t *testing.T) {
+	resourceName := "azurerm_scheduler_job_collection.test"
+
+	ri := acctest.RandInt()
+	config := testAccAzureRMSchedulerJobCollection_complete(ri, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  checkAccAzureRMSchedulerJobCollection_complete(resourceName),
+			},
+			{
+				// the imported state must match the created state (including the full `quota` block)
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -1,8 +1,13 @@
 package azurerm
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-06-01/subscriptions"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
@@ -34,15 +39,116 @@ func deprecatedLocationSchema() *schema.Schema {
 	}
 }
 
+// azureLocationDisplayNameReplacer removes the characters from a location's display name (e.g. `East US 2` or
+// `Central US (Stage)`) which aren't part of its location code (`eastus2` and `centralusstage`)
+var azureLocationDisplayNameReplacer = strings.NewReplacer(" ", "", "(", "", ")", "")
+
+// azureLocationAliases maps the (normalized) display names of locations which don't match their location code
+// once normalized, to that location code
+var azureLocationAliases = map[string]string{
+	"germanycentralsovereign":   "germanycentral",
+	"germanynortheastsovereign": "germanynortheast",
+}
+
 // azureRMNormalizeLocation is a function which normalises human-readable region/location
 // names (e.g. "West US") to the values used and returned by the Azure API (e.g. "westus").
 // In state we track the API internal version as it is easier to go from the human form
 // to the canonical form than the other way around.
 func azureRMNormalizeLocation(location interface{}) string {
 	input := location.(string)
-	return strings.Replace(strings.ToLower(input), " ", "", -1)
+	normalized := azureLocationDisplayNameReplacer.Replace(strings.ToLower(input))
+
+	if code, ok := azureLocationAliases[normalized]; ok {
+		return code
+	}
+
+	return normalized
 }
 
 func azureRMSuppressLocationDiff(k, old, new string, d *schema.ResourceData) bool {
 	return azureRMNormalizeLocation(old) == azureRMNormalizeLocation(new)
 }
+
+// azureLocations holds the (normalized) locations available to a Subscription, which are fetched when the Provider is
+// configured. This is empty when they couldn't be retrieved, in which case locations aren't validated.
+type azureLocations map[string]struct{}
+
+func newAzureLocations(locations []string) azureLocations {
+	output := make(azureLocations, len(locations))
+	for _, location := range locations {
+		output[azureRMNormalizeLocation(location)] = struct{}{}
+	}
+
+	return output
+}
+
+// contains returns whether the location is available - and if the available locations are known at all
+func (l azureLocations) contains(location string) (bool, bool) {
+	if len(l) == 0 {
+		return false, false
+	}
+
+	_, ok := l[azureRMNormalizeLocation(location)]
+	return ok, true
+}
+
+func (l azureLocations) list() []string {
+	locations := make([]string, 0, len(l))
+	for location := range l {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+
+	return locations
+}
+
+// loadAvailableAzureLocations retrieves the locations available to the Subscription. This is best-effort,
+// if they can't be retrieved location validation is skipped rather than failing.
+func loadAvailableAzureLocations(ctx context.Context, client subscriptions.Client, subscriptionId string) azureLocations {
+	resp, err := client.ListLocations(ctx, subscriptionId)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to list the Locations available to Subscription %q - locations won't be validated: %+v", subscriptionId, err)
+		return azureLocations{}
+	}
+
+	locations := make([]string, 0)
+	if values := resp.Value; values != nil {
+		for _, location := range *values {
+			if location.Name != nil {
+				locations = append(locations, *location.Name)
+			}
+		}
+	}
+
+	return newAzureLocations(locations)
+}
+
+// availableLocationCustomizeDiff wraps the CustomizeDiff of a resource which has a `location`, so that the plan fails
+// when the location isn't available to the Subscription the Provider is configured for
+func availableLocationCustomizeDiff(customizeDiff schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(diff *schema.ResourceDiff, v interface{}) error {
+		if client, ok := v.(*ArmClient); ok && (diff.Id() == "" || diff.HasChange("location")) {
+			// the location is empty when it's not known until apply
+			if location := diff.Get("location").(string); location != "" {
+				if err := validateAvailableAzureLocation(location, client.availableLocations); err != nil {
+					return err
+				}
+			}
+		}
+
+		if customizeDiff != nil {
+			return customizeDiff(diff, v)
+		}
+
+		return nil
+	}
+}
+
+func validateAvailableAzureLocation(location string, locations azureLocations) error {
+	available, known := locations.contains(location)
+	if known && !available {
+		return fmt.Errorf("`location` %q is not available in this Subscription, expected one of: %s", location, strings.Join(locations.list(), ", "))
+	}
+
+	return nil
+}
@@ -1,6 +1,12 @@
 package azurerm
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
 
 func TestAzureRMNormalizeLocation(t *testing.T) {
 	s := azureRMNormalizeLocation("West US")
@@ -8,3 +14,114 @@ func TestAzureRMNormalizeLocation(t *testing.T) {
 		t.Fatalf("expected location to equal westus, actual %s", s)
 	}
 }
+
+func TestAzureRMNormalizeLocation_displayNames(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"eastus2", "eastus2"},
+		{"East US 2", "eastus2"},
+		{"east us 2", "eastus2"},
+		{"EastUS2", "eastus2"},
+		{"East US 2 EUAP", "eastus2euap"},
+		{"Central US (Stage)", "centralusstage"},
+		{"USGov Virginia", "usgovvirginia"},
+		{"Germany Central (Sovereign)", "germanycentral"},
+		{"germanynortheast", "germanynortheast"},
+		{"Germany Northeast (Sovereign)", "germanynortheast"},
+	}
+
+	for _, test := range testCases {
+		if actual := azureRMNormalizeLocation(test.input); actual != test.expected {
+			t.Fatalf("Expected %q to be normalized to %q but got %q", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestAzureRMSuppressLocationDiff(t *testing.T) {
+	testCases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{"eastus2", "East US 2", true},
+		{"eastus2", "eastus 2", true},
+		{"germanycentral", "Germany Central (Sovereign)", true},
+		{"eastus", "East US 2", false},
+		{"westus", "West US 2", false},
+	}
+
+	for _, test := range testCases {
+		if actual := azureRMSuppressLocationDiff("location", test.old, test.new, nil); actual != test.suppress {
+			t.Fatalf("Expected the diff between %q and %q to be suppressed %t but got %t", test.old, test.new, test.suppress, actual)
+		}
+	}
+}
+
+func TestValidateAvailableAzureLocation(t *testing.T) {
+	// when the available locations aren't known everything is accepted
+	if err := validateAvailableAzureLocation("westus99", azureLocations{}); err != nil {
+		t.Fatalf("Expected no error when the available locations are unknown but got: %+v", err)
+	}
+
+	locations := newAzureLocations([]string{"westus", "westeurope"})
+
+	testCases := []struct {
+		location    string
+		shouldError bool
+	}{
+		{"westus", false},
+		{"West US", false},
+		{"WESTEUROPE", false},
+		{"West Europe", false},
+		{"westus99", true},
+		{"eastus", true},
+	}
+
+	for _, test := range testCases {
+		err := validateAvailableAzureLocation(test.location, locations)
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected an error validating %q but didn't get one", test.location)
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected no error validating %q but got: %+v", test.location, err)
+		}
+	}
+}
+
+func TestAvailableLocationCustomizeDiff(t *testing.T) {
+	// aliased Providers can be configured for Subscriptions with different locations available
+	westEurope := &ArmClient{availableLocations: newAzureLocations([]string{"westeurope"})}
+	chinaNorth := &ArmClient{availableLocations: newAzureLocations([]string{"chinanorth"})}
+
+	r := Provider().(*schema.Provider).ResourcesMap["azurerm_resource_group"]
+
+	testCases := []struct {
+		client      *ArmClient
+		location    string
+		shouldError bool
+	}{
+		{westEurope, "West Europe", false},
+		{westEurope, "chinanorth", true},
+		{chinaNorth, "China North", false},
+		{chinaNorth, "westeurope", true},
+	}
+
+	for _, test := range testCases {
+		raw := map[string]interface{}{
+			"name":     "group1",
+			"location": test.location,
+		}
+
+		_, err := r.Diff(nil, terraform.NewResourceConfig(config.TestRawConfig(t, raw)), test.client)
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected an error planning the location %q when only %q are available but didn't get one", test.location, test.client.availableLocations.list())
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected no error planning the location %q when %q are available but got: %+v", test.location, test.client.availableLocations.list(), err)
+		}
+	}
+}
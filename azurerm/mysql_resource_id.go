@@ -0,0 +1,74 @@
+package azurerm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MySQLServerChildID represents a parsed Resource ID for a resource nested within a MySQL Server,
+// such as a Database or a Firewall Rule.
+type MySQLServerChildID struct {
+	ResourceGroup string
+	ServerName    string
+	Name          string
+}
+
+func parseMySQLConfigurationID(id string) (*MySQLServerChildID, error) {
+	return parseMySQLServerChildID(id, "configurations", "MySQL Configuration")
+}
+
+func parseMySQLDatabaseID(id string) (*MySQLServerChildID, error) {
+	return parseMySQLServerChildID(id, "databases", "MySQL Database")
+}
+
+func parseMySQLFirewallRuleID(id string) (*MySQLServerChildID, error) {
+	return parseMySQLServerChildID(id, "firewallRules", "MySQL Firewall Rule")
+}
+
+func parseMySQLVirtualNetworkRuleID(id string) (*MySQLServerChildID, error) {
+	return parseMySQLServerChildID(id, "virtualNetworkRules", "MySQL Virtual Network Rule")
+}
+
+// parseMySQLServerChildID parses the Resource ID of a resource nested within a MySQL Server. The segment names
+// are matched case-insensitively, since the API isn't consistent about their casing (e.g. `firewallrules`).
+func parseMySQLServerChildID(input, childSegment, description string) (*MySQLServerChildID, error) {
+	id, err := parseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing %s ID %q: %+v", description, input, err)
+	}
+
+	if !strings.EqualFold(id.Provider, "Microsoft.DBforMySQL") {
+		return nil, fmt.Errorf("Expected the %s ID %q to be for the `Microsoft.DBforMySQL` provider but got %q", description, input, id.Provider)
+	}
+
+	// the Server plus the nested resource
+	if len(id.Path) != 2 {
+		return nil, fmt.Errorf("Expected the %s ID %q to contain 2 segments after the provider but got %d", description, input, len(id.Path))
+	}
+
+	serverName := resourceIDPathValueIgnoringCase(id, "servers")
+	if serverName == "" {
+		return nil, fmt.Errorf("Expected the %s ID %q to contain a `servers` segment", description, input)
+	}
+
+	name := resourceIDPathValueIgnoringCase(id, childSegment)
+	if name == "" {
+		return nil, fmt.Errorf("Expected the %s ID %q to contain a `%s` segment", description, input, childSegment)
+	}
+
+	return &MySQLServerChildID{
+		ResourceGroup: id.ResourceGroup,
+		ServerName:    serverName,
+		Name:          name,
+	}, nil
+}
+
+func resourceIDPathValueIgnoringCase(id *ResourceID, key string) string {
+	for k, v := range id.Path {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+
+	return ""
+}
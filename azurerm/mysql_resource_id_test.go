@@ -0,0 +1,109 @@
+package azurerm
+
+import "testing"
+
+func TestParseMySQLFirewallRuleID(t *testing.T) {
+	cases := []struct {
+		Input       string
+		Expected    *MySQLServerChildID
+		ExpectError bool
+	}{
+		{
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			// missing the firewall rule
+			Input:       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1",
+			ExpectError: true,
+		},
+		{
+			// a database rather than a firewall rule
+			Input:       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1/databases/db1",
+			ExpectError: true,
+		},
+		{
+			// a different provider
+			Input:       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforPostgreSQL/servers/server1/firewallRules/rule1",
+			ExpectError: true,
+		},
+		{
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1/firewallRules/rule1",
+			Expected: &MySQLServerChildID{
+				ResourceGroup: "group1",
+				ServerName:    "server1",
+				Name:          "rule1",
+			},
+		},
+		{
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1/firewallrules/rule1",
+			Expected: &MySQLServerChildID{
+				ResourceGroup: "group1",
+				ServerName:    "server1",
+				Name:          "rule1",
+			},
+		},
+		{
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group1/providers/microsoft.dbformysql/Servers/server1/FirewallRules/rule1",
+			Expected: &MySQLServerChildID{
+				ResourceGroup: "group1",
+				ServerName:    "server1",
+				Name:          "rule1",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		actual, err := parseMySQLFirewallRuleID(tc.Input)
+		if err != nil {
+			if tc.ExpectError {
+				continue
+			}
+
+			t.Fatalf("Expected no error parsing %q but got: %+v", tc.Input, err)
+		}
+
+		if tc.ExpectError {
+			t.Fatalf("Expected an error parsing %q but didn't get one", tc.Input)
+		}
+
+		if *actual != *tc.Expected {
+			t.Fatalf("Expected %+v but got %+v when parsing %q", *tc.Expected, *actual, tc.Input)
+		}
+	}
+}
+
+func TestParseMySQLServerChildIDs(t *testing.T) {
+	cases := []struct {
+		Parse func(string) (*MySQLServerChildID, error)
+		Input string
+	}{
+		{
+			Parse: parseMySQLConfigurationID,
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1/configurations/child1",
+		},
+		{
+			Parse: parseMySQLDatabaseID,
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1/databases/child1",
+		},
+		{
+			Parse: parseMySQLVirtualNetworkRuleID,
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1/virtualnetworkrules/child1",
+		},
+	}
+
+	for _, tc := range cases {
+		actual, err := tc.Parse(tc.Input)
+		if err != nil {
+			t.Fatalf("Expected no error parsing %q but got: %+v", tc.Input, err)
+		}
+
+		if actual.ResourceGroup != "group1" || actual.ServerName != "server1" || actual.Name != "child1" {
+			t.Fatalf("Expected %q to be parsed into group1/server1/child1 but got %+v", tc.Input, *actual)
+		}
+
+		if _, err := parseMySQLFirewallRuleID(tc.Input); err == nil {
+			t.Fatalf("Expected %q not to be parsed as a Firewall Rule ID", tc.Input)
+		}
+	}
+}
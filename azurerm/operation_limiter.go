@@ -0,0 +1,45 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+)
+
+// operationLimiter caps the number of Long Running Operations which can be in progress at once for a service, since
+// running many in parallel can exceed the operation limits of the subscription. A limit of 0 means no limit is applied.
+type operationLimiter struct {
+	slots chan struct{}
+}
+
+func newOperationLimiter(limit int) *operationLimiter {
+	if limit <= 0 {
+		return &operationLimiter{}
+	}
+
+	return &operationLimiter{
+		slots: make(chan struct{}, limit),
+	}
+}
+
+// acquire blocks until an operation can be started, or the context is cancelled - when this returns
+// without an error `release` must be called once the operation has completed.
+func (l *operationLimiter) acquire(ctx context.Context, description string) error {
+	if l == nil || l.slots == nil {
+		return nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("Error waiting to start %s: %+v", description, ctx.Err())
+	}
+}
+
+func (l *operationLimiter) release() {
+	if l == nil || l.slots == nil {
+		return
+	}
+
+	<-l.slots
+}
@@ -0,0 +1,77 @@
+package azurerm
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOperationLimiter_enforcesLimit(t *testing.T) {
+	limiter := newOperationLimiter(2)
+
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := limiter.acquire(context.Background(), "the test operation"); err != nil {
+				t.Errorf("Expected no error acquiring the limiter but got: %+v", err)
+				return
+			}
+			defer limiter.release()
+
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+
+	wg.Wait()
+
+	if maxRunning != 2 {
+		t.Fatalf("Expected at most 2 operations to run concurrently but got %d", maxRunning)
+	}
+}
+
+func TestOperationLimiter_cancelled(t *testing.T) {
+	limiter := newOperationLimiter(1)
+	if err := limiter.acquire(context.Background(), "the first operation"); err != nil {
+		t.Fatalf("Expected no error acquiring the limiter but got: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.acquire(ctx, "the second operation"); err == nil {
+		t.Fatalf("Expected an error acquiring the limiter once the context was cancelled")
+	}
+
+	limiter.release()
+	if err := limiter.acquire(context.Background(), "the third operation"); err != nil {
+		t.Fatalf("Expected no error acquiring the limiter once released but got: %+v", err)
+	}
+}
+
+func TestOperationLimiter_unlimited(t *testing.T) {
+	for _, limiter := range []*operationLimiter{nil, newOperationLimiter(0)} {
+		for i := 0; i < 100; i++ {
+			if err := limiter.acquire(context.Background(), "the test operation"); err != nil {
+				t.Fatalf("Expected no error acquiring an unlimited limiter but got: %+v", err)
+			}
+		}
+
+		limiter.release()
+	}
+}
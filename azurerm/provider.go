@@ -9,11 +9,14 @@ import (
 	"log"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2017-05-10/resources"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/authentication"
 )
@@ -74,6 +77,66 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_MSI_ENDPOINT", ""),
 			},
+
+			"scheduler_api_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_SCHEDULER_API_VERSION", schedulerDefaultAPIVersion),
+			},
+
+			"polling_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_POLLING_INTERVAL", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"max_concurrent_mysql_operations": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MAX_CONCURRENT_MYSQL_OPERATIONS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_REQUEST_TIMEOUT", 60),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"ca_bundle_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_CA_BUNDLE_PATH", ""),
+			},
+
+			"requires_import": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_REQUIRES_IMPORT", false),
+			},
+
+			"ignore_system_tags": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_IGNORE_SYSTEM_TAGS", false),
+			},
+
+			"ignore_forbidden_reads": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_IGNORE_FORBIDDEN_READS", false),
+			},
+
+			"required_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -87,6 +150,11 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_image":                                 dataSourceArmImage(),
 			"azurerm_key_vault_access_policy":               dataSourceArmKeyVaultAccessPolicy(),
 			"azurerm_managed_disk":                          dataSourceArmManagedDisk(),
+			"azurerm_mysql_databases":                       dataSourceArmMySQLDatabases(),
+			"azurerm_mysql_name_availability":               dataSourceArmMySQLNameAvailability(),
+			"azurerm_mysql_server":                          dataSourceArmMySqlServer(),
+			"azurerm_mysql_server_connectivity":             dataSourceArmMySQLServerConnectivity(),
+			"azurerm_mysql_server_log_files":                dataSourceArmMySQLServerLogFiles(),
 			"azurerm_network_interface":                     dataSourceArmNetworkInterface(),
 			"azurerm_network_security_group":                dataSourceArmNetworkSecurityGroup(),
 			"azurerm_platform_image":                        dataSourceArmPlatformImage(),
@@ -95,6 +163,8 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_resource_group":                        dataSourceArmResourceGroup(),
 			"azurerm_role_definition":                       dataSourceArmRoleDefinition(),
 			"azurerm_scheduler_job_collection":              dataSourceArmSchedulerJobCollection(),
+			"azurerm_scheduler_job_collections":             dataSourceArmSchedulerJobCollections(),
+			"azurerm_scheduler_sku_quotas":                  dataSourceArmSchedulerSkuQuotas(),
 			"azurerm_snapshot":                              dataSourceArmSnapshot(),
 			"azurerm_storage_account":                       dataSourceArmStorageAccount(),
 			"azurerm_subnet":                                dataSourceArmSubnet(),
@@ -161,7 +231,9 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_managed_disk":                        resourceArmManagedDisk(),
 			"azurerm_management_lock":                     resourceArmManagementLock(),
 			"azurerm_metric_alertrule":                    resourceArmMetricAlertRule(),
+			"azurerm_monitor_diagnostic_setting":          resourceArmMonitorDiagnosticSetting(),
 			"azurerm_mysql_configuration":                 resourceArmMySQLConfiguration(),
+			"azurerm_mysql_configurations":                resourceArmMySQLConfigurations(),
 			"azurerm_mysql_database":                      resourceArmMySqlDatabase(),
 			"azurerm_mysql_firewall_rule":                 resourceArmMySqlFirewallRule(),
 			"azurerm_mysql_server":                        resourceArmMySqlServer(),
@@ -218,13 +290,36 @@ func Provider() terraform.ResourceProvider {
 		},
 	}
 
+	for _, r := range p.ResourcesMap {
+		// any `required_tags` configured in the Provider must be specified on each resource which supports tags
+		if s, ok := r.Schema["tags"]; ok && s.Type == schema.TypeMap && s.Optional {
+			r.CustomizeDiff = requiredTagsCustomizeDiff(r.CustomizeDiff)
+			r.Create = requiredTagsCreateUpdate(r.Create)
+			if r.Update != nil {
+				r.Update = requiredTagsCreateUpdate(r.Update)
+			}
+		}
+
+		// the `location` of each resource must be available to the Subscription which the Provider is configured for
+		if s, ok := r.Schema["location"]; ok && s.Type == schema.TypeString && s.Required {
+			r.CustomizeDiff = availableLocationCustomizeDiff(r.CustomizeDiff)
+		}
+	}
+
 	p.ConfigureFunc = providerConfigure(p)
 
 	return p
 }
 
-func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
+// providerConfigure returns the ConfigureFunc for the provider - any `decorators` are applied to the Sender used by
+// all of the clients.
+func providerConfigure(p *schema.Provider, decorators ...autorest.SendDecorator) schema.ConfigureFunc {
 	return func(d *schema.ResourceData) (interface{}, error) {
+		requiredTags := make([]string, 0)
+		for _, v := range d.Get("required_tags").([]interface{}) {
+			requiredTags = append(requiredTags, v.(string))
+		}
+
 		config := &authentication.Config{
 			SubscriptionID:            d.Get("subscription_id").(string),
 			ClientID:                  d.Get("client_id").(string),
@@ -237,6 +332,18 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 			SkipProviderRegistration:  d.Get("skip_provider_registration").(bool),
 		}
 
+		options := providerOptions{
+			schedulerAPIVersion:   d.Get("scheduler_api_version").(string),
+			pollingInterval:       time.Duration(d.Get("polling_interval").(int)) * time.Second,
+			maxConcurrentMySQLOps: d.Get("max_concurrent_mysql_operations").(int),
+			requiresImport:        d.Get("requires_import").(bool),
+			ignoreSystemTags:      d.Get("ignore_system_tags").(bool),
+			ignoreForbiddenReads:  d.Get("ignore_forbidden_reads").(bool),
+			requiredTags:          requiredTags,
+			caBundlePath:          d.Get("ca_bundle_path").(string),
+			requestTimeout:        time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		}
+
 		if config.UseMsi {
 			log.Printf("[DEBUG] use_msi specified - using MSI Authentication")
 			if config.MsiEndpoint == "" {
@@ -267,7 +374,7 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 			}
 		}
 
-		client, err := getArmClient(config)
+		client, err := getArmClient(config, options, decorators...)
 		if err != nil {
 			return nil, err
 		}
@@ -297,6 +404,9 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 					return nil, err
 				}
 			}
+
+			// used to validate the `location` field on resources, on a best-effort basis
+			client.availableLocations = loadAvailableAzureLocations(ctx, client.subscriptionsClient, client.subscriptionId)
 		}
 
 		return client, nil
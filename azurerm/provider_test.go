@@ -3,6 +3,7 @@ package azurerm
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -111,7 +112,7 @@ func TestAccAzureRMResourceProviderRegistration(t *testing.T) {
 		return
 	}
 
-	armClient, err := getArmClient(config)
+	armClient, err := getArmClient(config, providerOptions{})
 	if err != nil {
 		t.Fatalf("Error building ARM Client: %+v", err)
 	}
@@ -135,3 +136,9 @@ func TestAccAzureRMResourceProviderRegistration(t *testing.T) {
 		t.Fatalf("'%d' Resource Providers are still Pending Registration: %s", len(needingRegistration), spew.Sprint(needingRegistration))
 	}
 }
+
+// testRequiresImportError matches the error returned when creating a resource which already exists with `requires_import` enabled
+func testRequiresImportError(resourceName string) *regexp.Regexp {
+	message := "to be managed via Terraform this resource needs to be imported into the State. Please see the resource documentation for %q for more information."
+	return regexp.MustCompile(regexp.QuoteMeta(fmt.Sprintf(message, resourceName)))
+}
@@ -0,0 +1,53 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// provisioningStateRefreshFunc retrieves the resource and returns its current provisioning state
+type provisioningStateRefreshFunc func() (string, error)
+
+// waitForProvisioningState polls the resource until its provisioning state reaches one of the `target` states.
+// An error is returned if the resource reaches a state which is neither `pending` nor `target` (for example
+// a failed state), if retrieving the resource fails permanently or if the `timeout` is reached - should retrieving
+// the resource fail temporarily (see `armErrorIsRetriable`) it's polled again.
+func waitForProvisioningState(description string, pending []string, target []string, timeout time.Duration, refresh provisioningStateRefreshFunc) error {
+	log.Printf("[DEBUG] Waiting for %s to reach the provisioning state %q", description, target)
+
+	// the last known state is returned whilst retrieving the resource is failing temporarily
+	lastState := ""
+	if len(pending) > 0 {
+		lastState = pending[0]
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: pending,
+		Target:  target,
+		Refresh: func() (interface{}, string, error) {
+			state, err := refresh()
+			if err != nil {
+				if armErrorIsRetriable(err) {
+					log.Printf("[DEBUG] Retrieving %s failed temporarily - polling again: %+v", description, err)
+					return lastState, lastState, nil
+				}
+
+				return nil, "", err
+			}
+
+			log.Printf("[DEBUG] %s has the provisioning state %q", description, state)
+			lastState = state
+			return state, state, nil
+		},
+		Timeout: timeout,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for %s to finish provisioning: %+v", description, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestWaitForProvisioningState(t *testing.T) {
+	testCases := []struct {
+		name          string
+		states        []string
+		err           error
+		expectedCalls int
+		expectError   bool
+	}{
+		{
+			name:          "already provisioned",
+			states:        []string{"Ready"},
+			expectedCalls: 1,
+			expectError:   false,
+		},
+		{
+			name:          "provisioned after polling",
+			states:        []string{"", "", "Ready"},
+			expectedCalls: 3,
+			expectError:   false,
+		},
+		{
+			name:          "unexpected terminal state",
+			states:        []string{"", "Dropping"},
+			expectedCalls: 2,
+			expectError:   true,
+		},
+		{
+			name:          "error retrieving the resource",
+			states:        []string{""},
+			err:           fmt.Errorf("retrieving the resource failed"),
+			expectedCalls: 1,
+			expectError:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		calls := 0
+		refresh := func() (string, error) {
+			calls++
+			if test.err != nil {
+				return "", test.err
+			}
+
+			return test.states[calls-1], nil
+		}
+
+		err := waitForProvisioningState("the resource", []string{""}, []string{"Ready"}, time.Minute, refresh)
+		if test.expectError && err == nil {
+			t.Fatalf("Expected an error for %q but didn't get one", test.name)
+		}
+
+		if !test.expectError && err != nil {
+			t.Fatalf("Expected no error for %q but got: %+v", test.name, err)
+		}
+
+		if calls != test.expectedCalls {
+			t.Fatalf("Expected %d calls for %q but got %d", test.expectedCalls, test.name, calls)
+		}
+	}
+}
+
+func TestWaitForProvisioningState_timeout(t *testing.T) {
+	refresh := func() (string, error) {
+		return "", nil
+	}
+
+	if err := waitForProvisioningState("the resource", []string{""}, []string{"Ready"}, time.Second, refresh); err == nil {
+		t.Fatalf("Expected an error when the resource is never provisioned but didn't get one")
+	}
+}
+
+func TestWaitForProvisioningState_temporaryError(t *testing.T) {
+	throttled := autorest.DetailedError{
+		Response: &http.Response{StatusCode: http.StatusTooManyRequests},
+	}
+
+	calls := 0
+	refresh := func() (string, error) {
+		calls++
+		if calls == 1 {
+			return "", throttled
+		}
+
+		return "Ready", nil
+	}
+
+	if err := waitForProvisioningState("the resource", []string{""}, []string{"Ready"}, time.Minute, refresh); err != nil {
+		t.Fatalf("Expected no error when retrieving the resource fails temporarily but got: %+v", err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("Expected 2 calls but got %d", calls)
+	}
+}
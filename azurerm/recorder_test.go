@@ -0,0 +1,429 @@
+package azurerm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// cassetteIgnoredHeaders are response headers which aren't recorded, either since they're sensitive or since
+// replaying them would slow down the tests (e.g. waiting before polling a Long Running Operation)
+var cassetteIgnoredHeaders = []string{
+	"Retry-After",
+	"Set-Cookie",
+}
+
+// cassette holds the HTTP interactions recorded whilst running an acceptance test against a real subscription, so
+// that the test can be run again by replaying them - without making any requests to Azure.
+type cassette struct {
+	// the subscription, random integer and location used when recording, since the replayed requests must match
+	SubscriptionID string `json:"subscription_id"`
+	RandomInt      int    `json:"random_int"`
+	Location       string `json:"location"`
+
+	Interactions []cassetteInteraction `json:"interactions"`
+
+	path string
+	lock sync.Mutex
+	used []bool
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+func newCassette(path string) *cassette {
+	return &cassette{
+		path:         path,
+		Interactions: make([]cassetteInteraction, 0),
+	}
+}
+
+func loadCassette(path string) (*cassette, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading the cassette %q: %+v", path, err)
+	}
+
+	c := newCassette(path)
+	if err := json.Unmarshal(contents, c); err != nil {
+		return nil, fmt.Errorf("Error parsing the cassette %q: %+v", path, err)
+	}
+	c.used = make([]bool, len(c.Interactions))
+
+	return c, nil
+}
+
+func (c *cassette) save() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error serializing the cassette %q: %+v", c.path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("Error creating the directory for the cassette %q: %+v", c.path, err)
+	}
+
+	if err := ioutil.WriteFile(c.path, append(contents, '\n'), 0644); err != nil {
+		return fmt.Errorf("Error writing the cassette %q: %+v", c.path, err)
+	}
+
+	return nil
+}
+
+// withRecording records each request (and the response returned for it) in the cassette - the credentials aren't
+// recorded and any SAS tokens/keys are redacted. Requests which fail without a response aren't recorded.
+func (c *cassette) withRecording() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			requestBody, err := readAndRestoreBody(&r.Body)
+			if err != nil {
+				return nil, fmt.Errorf("Error reading the body of the request to %s: %+v", r.URL, err)
+			}
+
+			resp, err := s.Do(r)
+			if resp == nil {
+				return resp, err
+			}
+
+			responseBody, readErr := readAndRestoreBody(&resp.Body)
+			if readErr != nil {
+				return resp, fmt.Errorf("Error reading the body of the response from %s: %+v", r.URL, readErr)
+			}
+
+			headers := make(http.Header)
+			for name, values := range resp.Header {
+				if cassetteHeaderIgnored(name) {
+					continue
+				}
+
+				for _, value := range values {
+					headers.Add(name, redactSASTokens(value))
+				}
+			}
+
+			c.lock.Lock()
+			c.Interactions = append(c.Interactions, cassetteInteraction{
+				Request: cassetteRequest{
+					Method: r.Method,
+					URL:    r.URL.String(),
+					Body:   redactSASTokens(requestBody),
+				},
+				Response: cassetteResponse{
+					StatusCode: resp.StatusCode,
+					Headers:    headers,
+					Body:       redactSASTokens(responseBody),
+				},
+			})
+			c.lock.Unlock()
+
+			return resp, err
+		})
+	}
+}
+
+// replayingSender returns a Sender which responds to each request using the first interaction in the cassette
+// for the same method and URL which hasn't been replayed yet - returning an error when there isn't one.
+func (c *cassette) replayingSender() autorest.Sender {
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		interaction, ok := c.nextInteraction(r.Method, r.URL.String())
+		if !ok {
+			return nil, fmt.Errorf("No interaction for %s %s was recorded in the cassette %q", r.Method, r.URL, c.path)
+		}
+
+		log.Printf("[DEBUG] Replaying the recorded response for %s %s", r.Method, r.URL)
+		resp := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+			StatusCode:    resp.StatusCode,
+			Header:        resp.Headers,
+			Body:          ioutil.NopCloser(strings.NewReader(resp.Body)),
+			ContentLength: int64(len(resp.Body)),
+			Request:       r,
+		}, nil
+	})
+}
+
+func (c *cassette) nextInteraction(method, url string) (cassetteInteraction, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i, v := range c.Interactions {
+		if c.used[i] || v.Request.Method != method || v.Request.URL != url {
+			continue
+		}
+
+		c.used[i] = true
+		return v, true
+	}
+
+	return cassetteInteraction{}, false
+}
+
+func cassetteHeaderIgnored(name string) bool {
+	for _, headers := range [][]string{armSensitiveHeaders, cassetteIgnoredHeaders} {
+		for _, v := range headers {
+			if strings.EqualFold(name, v) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// readAndRestoreBody returns the contents of the body, replacing it so that it can be read again
+func readAndRestoreBody(body *io.ReadCloser) (string, error) {
+	if *body == nil {
+		return "", nil
+	}
+
+	contents, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	*body = ioutil.NopCloser(bytes.NewReader(contents))
+	if err != nil {
+		return "", err
+	}
+
+	return string(contents), nil
+}
+
+// newArmClientWithSender returns an *ArmClient for the Public Cloud which sends all requests using the specified
+// Sender without authorizing them - such that the requests can be served without a real subscription, for example
+// when replaying the HTTP interactions recorded in a cassette.
+func newArmClientWithSender(subscriptionId string, sender autorest.Sender) *ArmClient {
+	env := azure.PublicCloud
+	auth := autorest.NullAuthorizer{}
+
+	client := ArmClient{
+		subscriptionId:           subscriptionId,
+		environment:              env,
+		skipProviderRegistration: true,
+		pollingInterval:          time.Millisecond,
+		mysqlOperationsLimiter:   newOperationLimiter(0),
+		sender:                   sender,
+		StopContext:              context.Background(),
+	}
+
+	client.registerClients(env.ResourceManagerEndpoint, env.GraphEndpoint, subscriptionId, "", auth, auth, auth, sender)
+
+	return &client
+}
+
+// testAccCassetteModeEnvVar can be set to `record` to run the acceptance tests which use a cassette against a real
+// subscription, recording the HTTP interactions - otherwise they're replayed from the cassette
+const testAccCassetteModeEnvVar = "ARM_TEST_CASSETTE_MODE"
+
+// testAccUseCassette configures the acceptance test provider to replay (or record) the HTTP interactions in the named
+// cassette. Since the replayed requests must match those which were recorded, this returns the random integer and
+// location to use in the configuration - along with a func which restores the provider, which must be deferred.
+func testAccUseCassette(t *testing.T, name string) (int, string, func()) {
+	path := filepath.Join("testdata", "cassettes", name+".json")
+
+	if os.Getenv(testAccCassetteModeEnvVar) == "record" {
+		testAccPreCheck(t)
+
+		c := newCassette(path)
+		c.SubscriptionID = os.Getenv("ARM_SUBSCRIPTION_ID")
+		c.RandomInt = acctest.RandInt()
+		c.Location = testLocation()
+
+		testAccProvider.ConfigureFunc = providerConfigure(testAccProvider, c.withRecording())
+		return c.RandomInt, c.Location, func() {
+			testAccProvider.ConfigureFunc = providerConfigure(testAccProvider)
+
+			if t.Failed() {
+				t.Logf("Not saving the cassette %q since the test failed", path)
+				return
+			}
+
+			if err := c.save(); err != nil {
+				t.Fatalf("Error saving the cassette: %+v", err)
+			}
+		}
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		t.Skipf("Skipping since the cassette %q hasn't been recorded - set `%s` to `record` to record it", path, testAccCassetteModeEnvVar)
+	}
+
+	c, err := loadCassette(path)
+	if err != nil {
+		t.Fatalf("Error loading the cassette: %+v", err)
+	}
+
+	testAccProvider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		client := newArmClientWithSender(c.SubscriptionID, c.replayingSender())
+		client.StopContext = testAccProvider.StopContext()
+		return client, nil
+	}
+	return c.RandomInt, c.Location, func() {
+		testAccProvider.ConfigureFunc = providerConfigure(testAccProvider)
+	}
+}
+
+func TestCassette_recordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatalf("Error creating a temporary directory: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	responses := []string{`{"state": "Creating"}`, `{"state": "Succeeded", "key": "AccountKey=secret"}`}
+	requests := 0
+	live := autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		body := responses[requests]
+		requests++
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type":  []string{"application/json"},
+				"Retry-After":   []string{"30"},
+				"Authorization": []string{"Bearer token"},
+			},
+			Body:    ioutil.NopCloser(strings.NewReader(body)),
+			Request: r,
+		}, nil
+	})
+
+	path := filepath.Join(dir, "cassettes", "test.json")
+	recording := newCassette(path)
+	recording.SubscriptionID = "00000000-0000-0000-0000-000000000000"
+	recording.RandomInt = 1234
+	sender := autorest.DecorateSender(live, recording.withRecording())
+
+	for i := range responses {
+		resp, err := sender.Do(testCassetteRequest(t, http.MethodGet, "https://management.azure.com/resource?api-version=2018-01-01"))
+		if err != nil {
+			t.Fatalf("Expected no error recording the request but got: %+v", err)
+		}
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		if string(body) != responses[i] {
+			t.Fatalf("Expected the recorded response body to still be readable as %q but got %q", responses[i], string(body))
+		}
+	}
+
+	if err := recording.save(); err != nil {
+		t.Fatalf("Expected no error saving the cassette but got: %+v", err)
+	}
+
+	replaying, err := loadCassette(path)
+	if err != nil {
+		t.Fatalf("Expected no error loading the cassette but got: %+v", err)
+	}
+
+	if replaying.SubscriptionID != recording.SubscriptionID || replaying.RandomInt != 1234 {
+		t.Fatalf("Expected the Subscription ID and random integer to be saved but got %q and %d", replaying.SubscriptionID, replaying.RandomInt)
+	}
+
+	expectedBodies := []string{`{"state": "Creating"}`, `{"state": "Succeeded", "key": "AccountKey=REDACTED"}`}
+	for _, expected := range expectedBodies {
+		resp, err := replaying.replayingSender().Do(testCassetteRequest(t, http.MethodGet, "https://management.azure.com/resource?api-version=2018-01-01"))
+		if err != nil {
+			t.Fatalf("Expected no error replaying the request but got: %+v", err)
+		}
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		if string(body) != expected {
+			t.Fatalf("Expected the replayed response body to be %q but got %q", expected, string(body))
+		}
+
+		for _, header := range []string{"Retry-After", "Authorization"} {
+			if v := resp.Header.Get(header); v != "" {
+				t.Fatalf("Expected the %q header not to be recorded but got %q", header, v)
+			}
+		}
+	}
+
+	if requests != len(responses) {
+		t.Fatalf("Expected %d requests to be made when recording but got %d", len(responses), requests)
+	}
+
+	// each of the recorded interactions has been replayed
+	if _, err := replaying.replayingSender().Do(testCassetteRequest(t, http.MethodGet, "https://management.azure.com/resource?api-version=2018-01-01")); err == nil {
+		t.Fatalf("Expected an error replaying a request which wasn't recorded")
+	}
+}
+
+func TestCassette_replayMatchesMethodAndURL(t *testing.T) {
+	c := newCassette("test.json")
+	c.Interactions = []cassetteInteraction{
+		{
+			Request:  cassetteRequest{Method: http.MethodGet, URL: "https://management.azure.com/first"},
+			Response: cassetteResponse{StatusCode: http.StatusOK, Body: "first"},
+		},
+		{
+			Request:  cassetteRequest{Method: http.MethodDelete, URL: "https://management.azure.com/second"},
+			Response: cassetteResponse{StatusCode: http.StatusAccepted},
+		},
+		{
+			Request:  cassetteRequest{Method: http.MethodGet, URL: "https://management.azure.com/second"},
+			Response: cassetteResponse{StatusCode: http.StatusNotFound},
+		},
+	}
+	c.used = make([]bool, len(c.Interactions))
+
+	testCases := []struct {
+		method     string
+		url        string
+		statusCode int
+	}{
+		{http.MethodGet, "https://management.azure.com/second", http.StatusNotFound},
+		{http.MethodGet, "https://management.azure.com/first", http.StatusOK},
+		{http.MethodDelete, "https://management.azure.com/second", http.StatusAccepted},
+	}
+
+	for _, test := range testCases {
+		resp, err := c.replayingSender().Do(testCassetteRequest(t, test.method, test.url))
+		if err != nil {
+			t.Fatalf("Expected no error replaying %s %s but got: %+v", test.method, test.url, err)
+		}
+
+		if resp.StatusCode != test.statusCode {
+			t.Fatalf("Expected %s %s to return %d but got %d", test.method, test.url, test.statusCode, resp.StatusCode)
+		}
+	}
+}
+
+func testCassetteRequest(t *testing.T, method, url string) *http.Request {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatalf("Error building the request: %+v", err)
+	}
+
+	return req
+}
@@ -0,0 +1,374 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/monitor/mgmt/2017-05-01-preview/insights"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmMonitorDiagnosticSetting() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmMonitorDiagnosticSettingCreateOrUpdate,
+		Read:   resourceArmMonitorDiagnosticSettingRead,
+		Update: resourceArmMonitorDiagnosticSettingCreateOrUpdate,
+		Delete: resourceArmMonitorDiagnosticSettingDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"target_resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAzureResourceID,
+			},
+
+			"storage_account_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAzureResourceID,
+			},
+
+			"eventhub_authorization_rule_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAzureResourceID,
+			},
+
+			"eventhub_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"log_analytics_workspace_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAzureResourceID,
+			},
+
+			"log": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"category": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"retention_policy": monitorDiagnosticSettingRetentionPolicySchema(),
+					},
+				},
+			},
+
+			"metric": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"category": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"retention_policy": monitorDiagnosticSettingRetentionPolicySchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func monitorDiagnosticSettingRetentionPolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Type:     schema.TypeBool,
+					Required: true,
+				},
+
+				"days": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+func resourceArmMonitorDiagnosticSettingCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).monitorDiagnosticSettingsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	targetResourceId := d.Get("target_resource_id").(string)
+
+	log.Printf("[INFO] preparing arguments for Monitor Diagnostic Setting %q (Resource %q).", name, targetResourceId)
+
+	storageAccountId := d.Get("storage_account_id").(string)
+	eventHubAuthorizationRuleId := d.Get("eventhub_authorization_rule_id").(string)
+	workspaceId := d.Get("log_analytics_workspace_id").(string)
+
+	if storageAccountId == "" && eventHubAuthorizationRuleId == "" && workspaceId == "" {
+		return fmt.Errorf("At least one of `storage_account_id`, `eventhub_authorization_rule_id` or `log_analytics_workspace_id` must be specified for Monitor Diagnostic Setting %q", name)
+	}
+
+	properties := insights.DiagnosticSettings{
+		Logs:    expandMonitorDiagnosticSettingLogs(d.Get("log").([]interface{})),
+		Metrics: expandMonitorDiagnosticSettingMetrics(d.Get("metric").([]interface{})),
+	}
+
+	if storageAccountId != "" {
+		properties.StorageAccountID = utils.String(storageAccountId)
+	}
+
+	if eventHubAuthorizationRuleId != "" {
+		properties.EventHubAuthorizationRuleID = utils.String(eventHubAuthorizationRuleId)
+
+		if eventHubName := d.Get("eventhub_name").(string); eventHubName != "" {
+			properties.EventHubName = utils.String(eventHubName)
+		}
+	}
+
+	if workspaceId != "" {
+		properties.WorkspaceID = utils.String(workspaceId)
+	}
+
+	parameters := insights.DiagnosticSettingsResource{
+		DiagnosticSettings: &properties,
+	}
+
+	if _, err := client.CreateOrUpdate(ctx, targetResourceId, parameters, name); err != nil {
+		return fmt.Errorf("Error creating/updating Monitor Diagnostic Setting %q (Resource %q): %+v", name, targetResourceId, err)
+	}
+
+	read, err := client.Get(ctx, targetResourceId, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Monitor Diagnostic Setting %q (Resource %q): %+v", name, targetResourceId, err)
+	}
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read ID of Monitor Diagnostic Setting %q (Resource %q)", name, targetResourceId)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmMonitorDiagnosticSettingRead(d, meta)
+}
+
+func resourceArmMonitorDiagnosticSettingRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).monitorDiagnosticSettingsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	targetResourceId, name, err := parseMonitorDiagnosticSettingId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, targetResourceId, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Monitor Diagnostic Setting %q (Resource %q) was not found - removing from state", name, targetResourceId)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Monitor Diagnostic Setting %q (Resource %q): %+v", name, targetResourceId, err)
+	}
+
+	d.Set("name", name)
+	d.Set("target_resource_id", targetResourceId)
+
+	if props := resp.DiagnosticSettings; props != nil {
+		d.Set("storage_account_id", props.StorageAccountID)
+		d.Set("eventhub_authorization_rule_id", props.EventHubAuthorizationRuleID)
+		d.Set("eventhub_name", props.EventHubName)
+		d.Set("log_analytics_workspace_id", props.WorkspaceID)
+
+		if err := d.Set("log", flattenMonitorDiagnosticSettingLogs(props.Logs)); err != nil {
+			return fmt.Errorf("Error setting `log`: %+v", err)
+		}
+
+		if err := d.Set("metric", flattenMonitorDiagnosticSettingMetrics(props.Metrics)); err != nil {
+			return fmt.Errorf("Error setting `metric`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceArmMonitorDiagnosticSettingDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).monitorDiagnosticSettingsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	targetResourceId, name, err := parseMonitorDiagnosticSettingId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Delete(ctx, targetResourceId, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting Monitor Diagnostic Setting %q (Resource %q): %+v", name, targetResourceId, err)
+	}
+
+	return nil
+}
+
+// parseMonitorDiagnosticSettingId splits the ID of a Diagnostic Setting into the ID of the Resource
+// it's attached to and the name of the Diagnostic Setting, since these are nested under any Resource
+// e.g. {targetResourceId}/providers/microsoft.insights/diagnosticSettings/{name}
+func parseMonitorDiagnosticSettingId(id string) (string, string, error) {
+	separator := "/providers/microsoft.insights/diagnosticsettings/"
+
+	index := strings.LastIndex(strings.ToLower(id), separator)
+	if index <= 0 {
+		return "", "", fmt.Errorf("Expected the Monitor Diagnostic Setting ID to be in the format `{targetResourceId}/providers/microsoft.insights/diagnosticSettings/{name}` but got %q", id)
+	}
+
+	targetResourceId := id[0:index]
+	name := id[index+len(separator):]
+	if name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("Expected the Monitor Diagnostic Setting ID to be in the format `{targetResourceId}/providers/microsoft.insights/diagnosticSettings/{name}` but got %q", id)
+	}
+
+	return targetResourceId, name, nil
+}
+
+func expandMonitorDiagnosticSettingLogs(input []interface{}) *[]insights.LogSettings {
+	results := make([]insights.LogSettings, 0)
+
+	for _, v := range input {
+		raw := v.(map[string]interface{})
+
+		results = append(results, insights.LogSettings{
+			Category:        utils.String(raw["category"].(string)),
+			Enabled:         utils.Bool(raw["enabled"].(bool)),
+			RetentionPolicy: expandMonitorDiagnosticSettingRetentionPolicy(raw["retention_policy"].([]interface{})),
+		})
+	}
+
+	return &results
+}
+
+func expandMonitorDiagnosticSettingMetrics(input []interface{}) *[]insights.MetricSettings {
+	results := make([]insights.MetricSettings, 0)
+
+	for _, v := range input {
+		raw := v.(map[string]interface{})
+
+		results = append(results, insights.MetricSettings{
+			Category:        utils.String(raw["category"].(string)),
+			Enabled:         utils.Bool(raw["enabled"].(bool)),
+			RetentionPolicy: expandMonitorDiagnosticSettingRetentionPolicy(raw["retention_policy"].([]interface{})),
+		})
+	}
+
+	return &results
+}
+
+func expandMonitorDiagnosticSettingRetentionPolicy(input []interface{}) *insights.RetentionPolicy {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	return &insights.RetentionPolicy{
+		Enabled: utils.Bool(raw["enabled"].(bool)),
+		Days:    utils.Int32(int32(raw["days"].(int))),
+	}
+}
+
+func flattenMonitorDiagnosticSettingLogs(input *[]insights.LogSettings) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, v := range *input {
+		output := make(map[string]interface{}, 0)
+
+		if v.Category != nil {
+			output["category"] = *v.Category
+		}
+		if v.Enabled != nil {
+			output["enabled"] = *v.Enabled
+		}
+		output["retention_policy"] = flattenMonitorDiagnosticSettingRetentionPolicy(v.RetentionPolicy)
+
+		results = append(results, output)
+	}
+
+	return results
+}
+
+func flattenMonitorDiagnosticSettingMetrics(input *[]insights.MetricSettings) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, v := range *input {
+		output := make(map[string]interface{}, 0)
+
+		if v.Category != nil {
+			output["category"] = *v.Category
+		}
+		if v.Enabled != nil {
+			output["enabled"] = *v.Enabled
+		}
+		output["retention_policy"] = flattenMonitorDiagnosticSettingRetentionPolicy(v.RetentionPolicy)
+
+		results = append(results, output)
+	}
+
+	return results
+}
+
+func flattenMonitorDiagnosticSettingRetentionPolicy(input *insights.RetentionPolicy) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make(map[string]interface{}, 0)
+	if input.Enabled != nil {
+		output["enabled"] = *input.Enabled
+	}
+	if input.Days != nil {
+		output["days"] = int(*input.Days)
+	}
+
+	return []interface{}{output}
+}
@@ -0,0 +1,182 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestParseMonitorDiagnosticSettingId(t *testing.T) {
+	testCases := []struct {
+		id                       string
+		expectedTargetResourceId string
+		expectedName             string
+		shouldError              bool
+	}{
+		{
+			id:          "",
+			shouldError: true,
+		},
+		{
+			id:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1",
+			shouldError: true,
+		},
+		{
+			id:          "/providers/microsoft.insights/diagnosticSettings/setting1",
+			shouldError: true,
+		},
+		{
+			id:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1/providers/microsoft.insights/diagnosticSettings/",
+			shouldError: true,
+		},
+		{
+			id:                       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1/providers/microsoft.insights/diagnosticSettings/setting1",
+			expectedTargetResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1",
+			expectedName:             "setting1",
+		},
+		{
+			id:                       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/providers/Microsoft.Insights/DiagnosticSettings/setting1",
+			expectedTargetResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+			expectedName:             "setting1",
+		},
+	}
+
+	for _, test := range testCases {
+		targetResourceId, name, err := parseMonitorDiagnosticSettingId(test.id)
+
+		if test.shouldError {
+			if err == nil {
+				t.Fatalf("Expected parsing %q to fail", test.id)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Expected parsing %q not to fail: %+v", test.id, err)
+		}
+
+		if targetResourceId != test.expectedTargetResourceId {
+			t.Fatalf("Expected the Target Resource ID to be %q but got %q", test.expectedTargetResourceId, targetResourceId)
+		}
+
+		if name != test.expectedName {
+			t.Fatalf("Expected the Name to be %q but got %q", test.expectedName, name)
+		}
+	}
+}
+
+func TestAccAzureRMMonitorDiagnosticSetting_schedulerJobCollection(t *testing.T) {
+	resourceName := "azurerm_monitor_diagnostic_setting.test"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(5)
+	config := testAccAzureRMMonitorDiagnosticSetting_schedulerJobCollection(ri, rs, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMonitorDiagnosticSettingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorDiagnosticSettingExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "target_resource_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "storage_account_id"),
+					resource.TestCheckResourceAttr(resourceName, "metric.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "metric.0.category", "AllMetrics"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMMonitorDiagnosticSettingExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		targetResourceId, name, err := parseMonitorDiagnosticSettingId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).monitorDiagnosticSettingsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, targetResourceId, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Monitor Diagnostic Setting %q (Resource %q) does not exist", name, targetResourceId)
+			}
+
+			return fmt.Errorf("Bad: Get on monitorDiagnosticSettingsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMMonitorDiagnosticSettingDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).monitorDiagnosticSettingsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_monitor_diagnostic_setting" {
+			continue
+		}
+
+		targetResourceId, name, err := parseMonitorDiagnosticSettingId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Get(ctx, targetResourceId, name)
+		if err != nil {
+			// the target resource is deleted alongside the Diagnostic Setting
+			if utils.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+
+			return err
+		}
+
+		return fmt.Errorf("Monitor Diagnostic Setting still exists:\n%#v", resp)
+	}
+
+	return nil
+}
+
+func testAccAzureRMMonitorDiagnosticSetting_schedulerJobCollection(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsads%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_monitor_diagnostic_setting" "test" {
+  name               = "acctestds-%d"
+  target_resource_id = "${azurerm_scheduler_job_collection.test.id}"
+  storage_account_id = "${azurerm_storage_account.test.id}"
+
+  metric {
+    category = "AllMetrics"
+
+    retention_policy {
+      enabled = true
+      days    = 7
+    }
+  }
+}
+`, testAccAzureRMSchedulerJobCollection_basic(rInt, location), rString, rInt)
+}
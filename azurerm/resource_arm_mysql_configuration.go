@@ -38,6 +38,12 @@ func resourceArmMySQLConfiguration() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+
+			// stored so that this can be reset to the default value when deleted - including when imported
+			"default_value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -87,13 +93,13 @@ func resourceArmMySQLConfigurationRead(d *schema.ResourceData, meta interface{})
 	client := meta.(*ArmClient).mysqlConfigurationsClient
 	ctx := meta.(*ArmClient).StopContext
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseMySQLConfigurationID(d.Id())
 	if err != nil {
 		return err
 	}
 	resourceGroup := id.ResourceGroup
-	serverName := id.Path["servers"]
-	name := id.Path["configurations"]
+	serverName := id.ServerName
+	name := id.Name
 
 	resp, err := client.Get(ctx, resourceGroup, serverName, name)
 	if err != nil {
@@ -103,13 +109,20 @@ func resourceArmMySQLConfigurationRead(d *schema.ResourceData, meta interface{})
 			return nil
 		}
 
-		return fmt.Errorf("Error making Read request on Azure MySQL Configuration %q (Resource Group %q): %+v", name, resourceGroup, err)
+		if readWasForbidden(meta.(*ArmClient), resp.Response, fmt.Sprintf("MySQL Configuration %q (Resource Group %q)", name, resourceGroup)) {
+			return nil
+		}
+
+		return fmt.Errorf("Error making Read request on Azure MySQL Configuration %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
 	}
 
 	d.Set("name", resp.Name)
 	d.Set("server_name", serverName)
 	d.Set("resource_group_name", resourceGroup)
-	d.Set("value", resp.ConfigurationProperties.Value)
+	if props := resp.ConfigurationProperties; props != nil {
+		d.Set("value", props.Value)
+		d.Set("default_value", props.DefaultValue)
+	}
 
 	return nil
 }
@@ -118,24 +131,29 @@ func resourceArmMySQLConfigurationDelete(d *schema.ResourceData, meta interface{
 	client := meta.(*ArmClient).mysqlConfigurationsClient
 	ctx := meta.(*ArmClient).StopContext
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseMySQLConfigurationID(d.Id())
 	if err != nil {
 		return err
 	}
 	resourceGroup := id.ResourceGroup
-	serverName := id.Path["servers"]
-	name := id.Path["configurations"]
+	serverName := id.ServerName
+	name := id.Name
 
 	// "delete" = resetting this to the default value
 	resp, err := client.Get(ctx, resourceGroup, serverName, name)
 	if err != nil {
-		return fmt.Errorf("Error retrieving MySQL Configuration %q (Resource Group %q): %+v", name, resourceGroup, err)
+		return fmt.Errorf("Error retrieving MySQL Configuration %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+	}
+
+	defaultValue, err := mysqlConfigurationDefaultValue(resp.ConfigurationProperties, d.Get("default_value").(string))
+	if err != nil {
+		return fmt.Errorf("Error resetting MySQL Configuration %q (Resource Group %q): %+v", name, resourceGroup, err)
 	}
 
 	properties := mysql.Configuration{
 		ConfigurationProperties: &mysql.ConfigurationProperties{
 			// we can alternatively set `source: "system-default"`
-			Value: resp.DefaultValue,
+			Value: utils.String(defaultValue),
 		},
 	}
 
@@ -151,3 +169,17 @@ func resourceArmMySQLConfigurationDelete(d *schema.ResourceData, meta interface{
 
 	return nil
 }
+
+// mysqlConfigurationDefaultValue returns the value a Configuration is reset to when it's deleted - which is the default
+// value returned from the API, falling back to the one stored in the state should the API not return it
+func mysqlConfigurationDefaultValue(props *mysql.ConfigurationProperties, stateDefaultValue string) (string, error) {
+	if props != nil && props.DefaultValue != nil {
+		return *props.DefaultValue, nil
+	}
+
+	if stateDefaultValue != "" {
+		return stateDefaultValue, nil
+	}
+
+	return "", fmt.Errorf("the default value of the Configuration couldn't be determined")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-04-30-preview/mysql"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
@@ -95,6 +96,64 @@ func TestAccAzureRMMySQLConfiguration_logSlowAdminStatements(t *testing.T) {
 	})
 }
 
+func TestMySQLConfigurationDefaultValue(t *testing.T) {
+	testCases := []struct {
+		props             *mysql.ConfigurationProperties
+		stateDefaultValue string
+		expected          string
+		shouldError       bool
+	}{
+		{
+			props:             &mysql.ConfigurationProperties{Value: utils.String("30"), DefaultValue: utils.String("28800")},
+			stateDefaultValue: "28800",
+			expected:          "28800",
+		},
+		{
+			// the API's default value takes precedence over the (possibly outdated) one in the state
+			props:             &mysql.ConfigurationProperties{DefaultValue: utils.String("latin1")},
+			stateDefaultValue: "utf8",
+			expected:          "latin1",
+		},
+		{
+			// some Configurations default to an empty value
+			props:    &mysql.ConfigurationProperties{Value: utils.String("SET NAMES utf8"), DefaultValue: utils.String("")},
+			expected: "",
+		},
+		{
+			props:             &mysql.ConfigurationProperties{Value: utils.String("30")},
+			stateDefaultValue: "28800",
+			expected:          "28800",
+		},
+		{
+			props:             nil,
+			stateDefaultValue: "28800",
+			expected:          "28800",
+		},
+		{
+			props:       &mysql.ConfigurationProperties{Value: utils.String("30")},
+			shouldError: true,
+		},
+	}
+
+	for i, test := range testCases {
+		actual, err := mysqlConfigurationDefaultValue(test.props, test.stateDefaultValue)
+		if test.shouldError {
+			if err == nil {
+				t.Fatalf("Expected test case %d to error", i)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Expected test case %d not to error but got: %+v", i, err)
+		}
+
+		if actual != test.expected {
+			t.Fatalf("Expected test case %d to return %q but got %q", i, test.expected, actual)
+		}
+	}
+}
+
 func testCheckAzureRMMySQLConfigurationValue(resourceName string, value string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// Ensure we have enough information in state to look up in API
@@ -0,0 +1,299 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-04-30-preview/mysql"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// the `source` of a MySQL Configuration which has been set to a non-default value
+const mysqlConfigurationSourceUserOverride = "user-override"
+
+func resourceArmMySQLConfigurations() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmMySQLConfigurationsCreateUpdate,
+		Read:   resourceArmMySQLConfigurationsRead,
+		Update: resourceArmMySQLConfigurationsCreateUpdate,
+		Delete: resourceArmMySQLConfigurationsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"server_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"configuration": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceArmMySQLConfigurationsCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).mysqlConfigurationsClient
+	serversClient := meta.(*ArmClient).mysqlServersClient
+	ctx := meta.(*ArmClient).StopContext
+
+	log.Printf("[INFO] preparing arguments for AzureRM MySQL Configurations creation.")
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	serverName := d.Get("server_name").(string)
+
+	server, err := serversClient.Get(ctx, resourceGroup, serverName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving MySQL Server %q (Resource Group %q): %s", serverName, resourceGroup, formatARMError(err))
+	}
+	if server.ID == nil {
+		return fmt.Errorf("Cannot read MySQL Server %q (Resource Group %q) ID", serverName, resourceGroup)
+	}
+
+	existing, err := client.ListByServer(ctx, resourceGroup, serverName)
+	if err != nil {
+		return fmt.Errorf("Error listing MySQL Configurations for MySQL Server %q (Resource Group %q): %s", serverName, resourceGroup, formatARMError(err))
+	}
+
+	desired := d.Get("configuration").(map[string]interface{})
+
+	// any Configurations which have been removed from the map are reset to their default value
+	removed := make([]string, 0)
+	if d.HasChange("configuration") {
+		old, _ := d.GetChange("configuration")
+		for name := range old.(map[string]interface{}) {
+			if _, ok := desired[name]; !ok {
+				removed = append(removed, name)
+			}
+		}
+	}
+
+	changes, err := mysqlConfigurationChanges(existing.Value, desired, removed)
+	if err != nil {
+		return fmt.Errorf("Error determining the MySQL Configurations to set on MySQL Server %q (Resource Group %q): %+v", serverName, resourceGroup, err)
+	}
+
+	// there's no batch API for Configurations, so only those which differ from the current value are set
+	for _, change := range changes {
+		if err := setMySQLServerConfiguration(ctx, client, resourceGroup, serverName, change.name, utils.String(change.value)); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(mysqlConfigurationsID(*server.ID))
+
+	return resourceArmMySQLConfigurationsRead(d, meta)
+}
+
+func resourceArmMySQLConfigurationsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).mysqlConfigurationsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resourceGroup, serverName, err := parseMySQLConfigurationsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ListByServer(ctx, resourceGroup, serverName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[WARN] MySQL Server %q was not found (resource group %q)", serverName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		if readWasForbidden(meta.(*ArmClient), resp.Response, fmt.Sprintf("the MySQL Configurations for MySQL Server %q (Resource Group %q)", serverName, resourceGroup)) {
+			return nil
+		}
+
+		return fmt.Errorf("Error listing MySQL Configurations for MySQL Server %q (Resource Group %q): %s", serverName, resourceGroup, formatARMError(err))
+	}
+
+	d.Set("server_name", serverName)
+	d.Set("resource_group_name", resourceGroup)
+
+	configuration := flattenMySQLConfigurations(resp.Value, d.Get("configuration").(map[string]interface{}))
+	if err := d.Set("configuration", configuration); err != nil {
+		return fmt.Errorf("Error flattening `configuration`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmMySQLConfigurationsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).mysqlConfigurationsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resourceGroup, serverName, err := parseMySQLConfigurationsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.ListByServer(ctx, resourceGroup, serverName)
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			return nil
+		}
+
+		return fmt.Errorf("Error listing MySQL Configurations for MySQL Server %q (Resource Group %q): %s", serverName, resourceGroup, formatARMError(err))
+	}
+
+	// "delete" = resetting each of these to the default value
+	removed := make([]string, 0)
+	for name := range d.Get("configuration").(map[string]interface{}) {
+		removed = append(removed, name)
+	}
+
+	changes, err := mysqlConfigurationChanges(existing.Value, map[string]interface{}{}, removed)
+	if err != nil {
+		return fmt.Errorf("Error determining the MySQL Configurations to reset on MySQL Server %q (Resource Group %q): %+v", serverName, resourceGroup, err)
+	}
+
+	for _, change := range changes {
+		if err := setMySQLServerConfiguration(ctx, client, resourceGroup, serverName, change.name, utils.String(change.value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// the ID of this resource is the ID of the MySQL Server with this suffix, so that the two resources don't share an ID
+const mysqlConfigurationsIDSuffix = "/configurations"
+
+func mysqlConfigurationsID(serverId string) string {
+	return serverId + mysqlConfigurationsIDSuffix
+}
+
+// parseMySQLConfigurationsID returns the Resource Group and the name of the MySQL Server from the ID of this resource
+func parseMySQLConfigurationsID(input string) (string, string, error) {
+	if !strings.HasSuffix(strings.ToLower(input), mysqlConfigurationsIDSuffix) {
+		return "", "", fmt.Errorf("Error parsing MySQL Configurations ID %q: expected the ID of a MySQL Server followed by %q", input, mysqlConfigurationsIDSuffix)
+	}
+
+	id, err := parseAzureResourceID(input[:len(input)-len(mysqlConfigurationsIDSuffix)])
+	if err != nil {
+		return "", "", fmt.Errorf("Error parsing MySQL Configurations ID %q: %+v", input, err)
+	}
+
+	serverName, ok := id.Path["servers"]
+	if !ok || serverName == "" || len(id.Path) != 1 {
+		return "", "", fmt.Errorf("Error parsing MySQL Configurations ID %q: expected the ID of a MySQL Server followed by %q", input, mysqlConfigurationsIDSuffix)
+	}
+
+	return id.ResourceGroup, serverName, nil
+}
+
+type mysqlConfigurationChange struct {
+	name  string
+	value string
+}
+
+// mysqlConfigurationChanges returns the Configurations which need to be set so that those in `desired` have the
+// specified value and those in `removed` have their default value - skipping any which are already set, since
+// each change is a separate long-running operation.
+func mysqlConfigurationChanges(existing *[]mysql.Configuration, desired map[string]interface{}, removed []string) ([]mysqlConfigurationChange, error) {
+	current := make(map[string]mysql.ConfigurationProperties)
+	if existing != nil {
+		for _, v := range *existing {
+			if v.Name == nil || v.ConfigurationProperties == nil {
+				continue
+			}
+
+			current[strings.ToLower(*v.Name)] = *v.ConfigurationProperties
+		}
+	}
+
+	changes := make([]mysqlConfigurationChange, 0)
+
+	for name, v := range desired {
+		value := v.(string)
+
+		props, ok := current[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("%q isn't a supported MySQL Configuration for this server", name)
+		}
+
+		if props.Value != nil && *props.Value == value {
+			continue
+		}
+
+		changes = append(changes, mysqlConfigurationChange{
+			name:  name,
+			value: value,
+		})
+	}
+
+	for _, name := range removed {
+		props, ok := current[strings.ToLower(name)]
+		if !ok || props.DefaultValue == nil {
+			// there's nothing to reset
+			continue
+		}
+
+		if props.Value != nil && *props.Value == *props.DefaultValue {
+			continue
+		}
+
+		changes = append(changes, mysqlConfigurationChange{
+			name:  name,
+			value: *props.DefaultValue,
+		})
+	}
+
+	// sort these so the changes are applied in a consistent order
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].name < changes[j].name
+	})
+
+	return changes, nil
+}
+
+// flattenMySQLConfigurations returns the current value of each of the `tracked` Configurations - or when there are
+// none (e.g. when importing) each of the Configurations which have been changed from their default value.
+func flattenMySQLConfigurations(input *[]mysql.Configuration, tracked map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{})
+	if input == nil {
+		return output
+	}
+
+	trackedNames := make(map[string]string)
+	for name := range tracked {
+		trackedNames[strings.ToLower(name)] = name
+	}
+
+	for _, v := range *input {
+		if v.Name == nil || v.ConfigurationProperties == nil || v.Value == nil {
+			continue
+		}
+
+		name := *v.Name
+		if len(tracked) > 0 {
+			trackedName, ok := trackedNames[strings.ToLower(name)]
+			if !ok {
+				continue
+			}
+			name = trackedName
+		} else if v.Source == nil || !strings.EqualFold(*v.Source, mysqlConfigurationSourceUserOverride) {
+			continue
+		}
+
+		output[name] = *v.Value
+	}
+
+	return output
+}
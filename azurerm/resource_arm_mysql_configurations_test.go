@@ -0,0 +1,290 @@
+package azurerm
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-04-30-preview/mysql"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func testMySQLConfiguration(name, value, defaultValue, source string) mysql.Configuration {
+	return mysql.Configuration{
+		Name: utils.String(name),
+		ConfigurationProperties: &mysql.ConfigurationProperties{
+			Value:        utils.String(value),
+			DefaultValue: utils.String(defaultValue),
+			Source:       utils.String(source),
+		},
+	}
+}
+
+func TestMySQLConfigurationChanges(t *testing.T) {
+	existing := []mysql.Configuration{
+		testMySQLConfiguration("character_set_server", "latin1", "latin1", "system-default"),
+		testMySQLConfiguration("interactive_timeout", "30", "600", mysqlConfigurationSourceUserOverride),
+		testMySQLConfiguration("log_slow_admin_statements", "ON", "OFF", mysqlConfigurationSourceUserOverride),
+		testMySQLConfiguration("slow_query_log", "OFF", "OFF", "system-default"),
+	}
+
+	cases := []struct {
+		Name        string
+		Desired     map[string]interface{}
+		Removed     []string
+		Expected    []mysqlConfigurationChange
+		ExpectError bool
+	}{
+		{
+			Name:     "nothing",
+			Desired:  map[string]interface{}{},
+			Expected: []mysqlConfigurationChange{},
+		},
+		{
+			Name: "unchanged values are skipped",
+			Desired: map[string]interface{}{
+				"interactive_timeout":  "30",
+				"character_set_server": "hebrew",
+			},
+			Expected: []mysqlConfigurationChange{
+				{name: "character_set_server", value: "hebrew"},
+			},
+		},
+		{
+			Name: "removed values are reset",
+			Desired: map[string]interface{}{
+				"slow_query_log": "ON",
+			},
+			Removed: []string{"interactive_timeout", "character_set_server"},
+			Expected: []mysqlConfigurationChange{
+				{name: "interactive_timeout", value: "600"},
+				{name: "slow_query_log", value: "ON"},
+			},
+		},
+		{
+			Name:     "removed values which no longer exist are ignored",
+			Desired:  map[string]interface{}{},
+			Removed:  []string{"does_not_exist"},
+			Expected: []mysqlConfigurationChange{},
+		},
+		{
+			Name: "unsupported configuration",
+			Desired: map[string]interface{}{
+				"does_not_exist": "1",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		actual, err := mysqlConfigurationChanges(&existing, tc.Desired, tc.Removed)
+		if err != nil {
+			if tc.ExpectError {
+				continue
+			}
+
+			t.Fatalf("%s: Expected no error but got: %+v", tc.Name, err)
+		}
+
+		if tc.ExpectError {
+			t.Fatalf("%s: Expected an error but didn't get one", tc.Name)
+		}
+
+		if !reflect.DeepEqual(tc.Expected, actual) {
+			t.Fatalf("%s: Expected %+v but got %+v", tc.Name, tc.Expected, actual)
+		}
+	}
+}
+
+func TestFlattenMySQLConfigurations(t *testing.T) {
+	input := []mysql.Configuration{
+		testMySQLConfiguration("character_set_server", "latin1", "latin1", "system-default"),
+		testMySQLConfiguration("interactive_timeout", "30", "600", mysqlConfigurationSourceUserOverride),
+		testMySQLConfiguration("log_slow_admin_statements", "ON", "OFF", mysqlConfigurationSourceUserOverride),
+	}
+
+	cases := []struct {
+		Name     string
+		Tracked  map[string]interface{}
+		Expected map[string]interface{}
+	}{
+		{
+			Name:    "import returns the overridden values",
+			Tracked: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"interactive_timeout":       "30",
+				"log_slow_admin_statements": "ON",
+			},
+		},
+		{
+			Name: "only the tracked values are returned",
+			Tracked: map[string]interface{}{
+				"CHARACTER_SET_SERVER": "hebrew",
+				"interactive_timeout":  "30",
+			},
+			Expected: map[string]interface{}{
+				"CHARACTER_SET_SERVER": "latin1",
+				"interactive_timeout":  "30",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		actual := flattenMySQLConfigurations(&input, tc.Tracked)
+		if !reflect.DeepEqual(tc.Expected, actual) {
+			t.Fatalf("%s: Expected %+v but got %+v", tc.Name, tc.Expected, actual)
+		}
+	}
+}
+
+func TestParseMySQLConfigurationsID(t *testing.T) {
+	serverId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1"
+
+	cases := []struct {
+		Input       string
+		ShouldError bool
+	}{
+		{Input: mysqlConfigurationsID(serverId), ShouldError: false},
+		{Input: serverId + "/Configurations", ShouldError: false},
+		// the ID of the MySQL Server itself is used by the `azurerm_mysql_server` resource
+		{Input: serverId, ShouldError: true},
+		{Input: serverId + "/databases/database1/configurations", ShouldError: true},
+		{Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/configurations", ShouldError: true},
+	}
+
+	for _, tc := range cases {
+		resourceGroup, serverName, err := parseMySQLConfigurationsID(tc.Input)
+		if tc.ShouldError {
+			if err == nil {
+				t.Fatalf("Expected an error parsing %q but didn't get one", tc.Input)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Expected no error parsing %q but got: %+v", tc.Input, err)
+		}
+
+		if resourceGroup != "group1" || serverName != "server1" {
+			t.Fatalf("Expected %q to be MySQL Server %q (Resource Group %q) but got %q (Resource Group %q)", tc.Input, "server1", "group1", serverName, resourceGroup)
+		}
+	}
+}
+
+func TestAccAzureRMMySQLConfigurations_basic(t *testing.T) {
+	resourceName := "azurerm_mysql_configurations.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+	serverOnlyConfig := testAccAzureRMMySQLConfiguration_empty(ri, location)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMySQLConfigurationsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMMySQLConfigurations_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "configuration.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.interactive_timeout", "30"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.character_set_server", "hebrew"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccAzureRMMySQLConfigurations_update(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "configuration.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.interactive_timeout", "60"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.log_slow_admin_statements", "on"),
+					// removing a value from the map resets it back to the default value
+					testCheckAzureRMMySQLConfigurationValueReset(ri, "character_set_server"),
+				),
+			},
+			{
+				Config: serverOnlyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					// "delete" resets back to the default values
+					testCheckAzureRMMySQLConfigurationValueReset(ri, "interactive_timeout"),
+					testCheckAzureRMMySQLConfigurationValueReset(ri, "log_slow_admin_statements"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMMySQLConfigurationsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).mysqlConfigurationsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_mysql_configurations" {
+			continue
+		}
+
+		serverName := rs.Primary.Attributes["server_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := client.ListByServer(ctx, resourceGroup, serverName)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+
+			return err
+		}
+
+		if resp.Value == nil {
+			continue
+		}
+
+		for _, v := range *resp.Value {
+			if v.Name == nil || v.ConfigurationProperties == nil || v.Value == nil || v.DefaultValue == nil {
+				continue
+			}
+
+			if _, ok := rs.Primary.Attributes[fmt.Sprintf("configuration.%s", *v.Name)]; ok && *v.Value != *v.DefaultValue {
+				return fmt.Errorf("MySQL Configuration %q (server %q resource group: %q) still has a non-default value %q", *v.Name, serverName, resourceGroup, *v.Value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccAzureRMMySQLConfigurations_basic(rInt int, location string) string {
+	server := testAccAzureRMMySQLConfiguration_empty(rInt, location)
+	return server + `
+resource "azurerm_mysql_configurations" "test" {
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  server_name         = "${azurerm_mysql_server.test.name}"
+
+  configuration {
+    character_set_server = "hebrew"
+    interactive_timeout  = "30"
+  }
+}
+`
+}
+
+func testAccAzureRMMySQLConfigurations_update(rInt int, location string) string {
+	server := testAccAzureRMMySQLConfiguration_empty(rInt, location)
+	return server + `
+resource "azurerm_mysql_configurations" "test" {
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  server_name         = "${azurerm_mysql_server.test.name}"
+
+  configuration {
+    interactive_timeout       = "60"
+    log_slow_admin_statements = "on"
+  }
+}
+`
+}
@@ -95,14 +95,14 @@ func resourceArmMySqlDatabaseRead(d *schema.ResourceData, meta interface{}) erro
 	client := meta.(*ArmClient).mysqlDatabasesClient
 	ctx := meta.(*ArmClient).StopContext
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseMySQLDatabaseID(d.Id())
 	if err != nil {
 		return err
 	}
 
 	resourceGroup := id.ResourceGroup
-	serverName := id.Path["servers"]
-	name := id.Path["databases"]
+	serverName := id.ServerName
+	name := id.Name
 
 	resp, err := client.Get(ctx, resourceGroup, serverName, name)
 	if err != nil {
@@ -110,7 +110,10 @@ func resourceArmMySqlDatabaseRead(d *schema.ResourceData, meta interface{}) erro
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error making Read request on Azure MySQL Database %q (Resource Group %q): %+v", name, resourceGroup, err)
+		if readWasForbidden(meta.(*ArmClient), resp.Response, fmt.Sprintf("MySQL Database %q (Resource Group %q)", name, resourceGroup)) {
+			return nil
+		}
+		return fmt.Errorf("Error making Read request on Azure MySQL Database %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
 	}
 
 	d.Set("name", resp.Name)
@@ -126,13 +129,13 @@ func resourceArmMySqlDatabaseDelete(d *schema.ResourceData, meta interface{}) er
 	client := meta.(*ArmClient).mysqlDatabasesClient
 	ctx := meta.(*ArmClient).StopContext
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseMySQLDatabaseID(d.Id())
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	serverName := id.Path["servers"]
-	name := id.Path["databases"]
+	serverName := id.ServerName
+	name := id.Name
 
 	future, err := client.Delete(ctx, resGroup, serverName, name)
 	if err != nil {
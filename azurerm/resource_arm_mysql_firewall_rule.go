@@ -93,13 +93,13 @@ func resourceArmMySqlFirewallRuleRead(d *schema.ResourceData, meta interface{})
 	client := meta.(*ArmClient).mysqlFirewallRulesClient
 	ctx := meta.(*ArmClient).StopContext
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseMySQLFirewallRuleID(d.Id())
 	if err != nil {
 		return err
 	}
 	resourceGroup := id.ResourceGroup
-	serverName := id.Path["servers"]
-	name := id.Path["firewallRules"]
+	serverName := id.ServerName
+	name := id.Name
 
 	resp, err := client.Get(ctx, resourceGroup, serverName, name)
 	if err != nil {
@@ -107,7 +107,10 @@ func resourceArmMySqlFirewallRuleRead(d *schema.ResourceData, meta interface{})
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error making Read request on Azure MySQL Firewall Rule %q (Resource Group %q): %+v", name, resourceGroup, err)
+		if readWasForbidden(meta.(*ArmClient), resp.Response, fmt.Sprintf("MySQL Firewall Rule %q (Resource Group %q)", name, resourceGroup)) {
+			return nil
+		}
+		return fmt.Errorf("Error making Read request on Azure MySQL Firewall Rule %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
 	}
 
 	d.Set("name", resp.Name)
@@ -123,13 +126,13 @@ func resourceArmMySqlFirewallRuleDelete(d *schema.ResourceData, meta interface{}
 	client := meta.(*ArmClient).mysqlFirewallRulesClient
 	ctx := meta.(*ArmClient).StopContext
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseMySQLFirewallRuleID(d.Id())
 	if err != nil {
 		return err
 	}
 	resourceGroup := id.ResourceGroup
-	serverName := id.Path["servers"]
-	name := id.Path["firewallRules"]
+	serverName := id.ServerName
+	name := id.Name
 
 	future, err := client.Delete(ctx, resourceGroup, serverName, name)
 	if err != nil {
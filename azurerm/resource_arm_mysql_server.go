@@ -1,16 +1,84 @@
 package azurerm
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-04-30-preview/mysql"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+const mysqlServerPort = 3306
+
+// mysqlServerCreateReadTimeout is how long to wait for a newly created MySQL Server to become available
+const mysqlServerCreateReadTimeout = 5 * time.Minute
+
+// mysqlServerCreateTimeout is how long we'll keep polling a creation which is failing temporarily (e.g. since it's throttled)
+const mysqlServerCreateTimeout = 60 * time.Minute
+
+// mysqlServerProvisioningTimeout is how long to wait for a newly created MySQL Server to become Ready
+const mysqlServerProvisioningTimeout = 30 * time.Minute
+
+// mysqlServerPendingDeletionTimeout is how long to wait for a previously deleted MySQL Server with the same name to be
+// purged, and mysqlServerPendingDeletionPollInterval how often the creation is retried whilst waiting
+const mysqlServerPendingDeletionTimeout = 10 * time.Minute
+const mysqlServerPendingDeletionPollInterval = 30 * time.Second
+
+// mysqlServerPendingDeletionErrorCode is the error code returned by Azure when the name is held by a deleted MySQL
+// Server which hasn't been purged
+const mysqlServerPendingDeletionErrorCode = "ServerPendingDeletion"
+
+// mysqlServerQueryStoreConfigurations maps the fields within the `query_store` block to the Server Configurations
+// they're stored in - in the order they need to be set, since wait sampling depends on the queries being captured
+var mysqlServerQueryStoreConfigurations = []struct {
+	field         string
+	configuration string
+}{
+	{"capture_mode", "query_store_capture_mode"},
+	{"wait_sampling_capture_mode", "query_store_wait_sampling_capture_mode"},
+}
+
+// mysqlServerConnectionLimitsConfigurations maps the fields within the `connection_limits` block to the Server Configurations
+// they're stored in - the maximum number of connections also depends on the Pricing Tier, which the API validates
+var mysqlServerConnectionLimitsConfigurations = []struct {
+	field         string
+	configuration string
+	min           int
+	max           int
+}{
+	{"max_connections", "max_connections", 10, 5000},
+	{"wait_timeout", "wait_timeout", 1, 31536000},
+	{"interactive_timeout", "interactive_timeout", 1, 31536000},
+}
+
+// the Server Configurations which the `audit_log` block is stored in
+const (
+	mysqlServerAuditLogEnabledConfiguration = "audit_log_enabled"
+	mysqlServerAuditLogEventsConfiguration  = "audit_log_events"
+)
+
+// mysqlServerAuditLogEvents are the types of event which can be written to the Audit Log
+var mysqlServerAuditLogEvents = []string{
+	"ADMIN",
+	"CONNECTION",
+	"DCL",
+	"DDL",
+	"DML",
+	"DML_NONSELECT",
+	"DML_SELECT",
+	"GENERAL",
+	"TABLE_ACCESS",
+}
+
 func resourceArmMySqlServer() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmMySqlServerCreate,
@@ -21,6 +89,8 @@ func resourceArmMySqlServer() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceArmMySqlServerCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -77,6 +147,31 @@ func resourceArmMySqlServer() *schema.Resource {
 				},
 			},
 
+			"create_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(mysql.CreateModeDefault),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(mysql.CreateModeDefault),
+					string(mysql.CreateModePointInTimeRestore),
+				}, true),
+				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+			},
+
+			"source_server_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"restore_point_in_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRFC3339Date,
+			},
+
 			"administrator_login": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -84,9 +179,17 @@ func resourceArmMySqlServer() *schema.Resource {
 			},
 
 			"administrator_login_password": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: mysqlServerWriteOnlyPasswordDiffSuppress,
+			},
+
+			// when specified the `administrator_login_password` is write-only - and is only sent when this changes
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 
 			"version": {
@@ -103,7 +206,6 @@ func resourceArmMySqlServer() *schema.Resource {
 			"storage_mb": {
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 				ValidateFunc: validateIntInSlice([]int{
 					// Basic SKU
 					51200,
@@ -137,16 +239,158 @@ func resourceArmMySqlServer() *schema.Resource {
 				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
 			},
 
+			//how long to wait after the Server is ready before the create completes, for systems which need it warmed up
+			"ready_delay": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+			},
+
+			"query_store": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"capture_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "ALL",
+							ValidateFunc: validation.StringInSlice([]string{
+								"ALL",
+								"NONE",
+							}, true),
+							DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+						},
+
+						"wait_sampling_capture_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "ALL",
+							ValidateFunc: validation.StringInSlice([]string{
+								"ALL",
+								"NONE",
+							}, true),
+							DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+						},
+					},
+				},
+			},
+
+			"connection_limits": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: mysqlServerConnectionLimitsSchema(),
+				},
+			},
+
+			"audit_log": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						// when this isn't specified the default events (currently only `CONNECTION`) are logged
+						"events": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(mysqlServerAuditLogEvents, false),
+							},
+							Set: schema.HashString,
+						},
+					},
+				},
+			},
+
 			"fqdn": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 
+			"connection_string": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ado_net_connection_string": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"jdbc_connection_string": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
 }
 
+func mysqlServerConnectionLimitsSchema() map[string]*schema.Schema {
+	output := make(map[string]*schema.Schema)
+	for _, v := range mysqlServerConnectionLimitsConfigurations {
+		output[v.field] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(v.min, v.max),
+		}
+	}
+	return output
+}
+
+func resourceArmMySqlServerCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+	if diff.Id() != "" && diff.HasChange("storage_mb") {
+		old, new := diff.GetChange("storage_mb")
+		if err := validateMySQLServerStorageMBChange(old.(int), new.(int)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mysqlServerWriteOnlyPasswordDiffSuppress ignores the `administrator_login_password` when it's write-only (and so
+// isn't stored in the state) unless `password_wo_version` has changed, which is used to rotate it
+func mysqlServerWriteOnlyPasswordDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" || old != "" {
+		return false
+	}
+
+	return d.Get("password_wo_version").(int) != 0 && !d.HasChange("password_wo_version")
+}
+
+// clearMySQLServerWriteOnlyPassword removes the `administrator_login_password` from the state once it's been sent
+// to Azure, when it's write-only
+func clearMySQLServerWriteOnlyPassword(d *schema.ResourceData) {
+	if d.Get("password_wo_version").(int) == 0 {
+		return
+	}
+
+	d.Set("administrator_login_password", "")
+}
+
+// validateMySQLServerStorageMBChange ensures the storage isn't being decreased, since Azure doesn't support
+// shrinking the storage of a MySQL Server (on any tier) - and recreating the Server would lose its data.
+func validateMySQLServerStorageMBChange(old int, new int) error {
+	if new < old {
+		return fmt.Errorf("`storage_mb` can't be decreased from %d to %d - Azure doesn't support reducing the storage of a MySQL Server", old, new)
+	}
+
+	return nil
+}
+
 func resourceArmMySqlServerCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).mysqlServersClient
 	ctx := meta.(*ArmClient).StopContext
@@ -167,40 +411,261 @@ func resourceArmMySqlServerCreate(d *schema.ResourceData, meta interface{}) erro
 
 	sku := expandMySQLServerSku(d, storageMB)
 
+	var serverProperties mysql.BasicServerPropertiesForCreate = &mysql.ServerPropertiesForDefaultCreate{
+		Version:                    mysql.ServerVersion(version),
+		StorageMB:                  utils.Int64(int64(storageMB)),
+		SslEnforcement:             mysql.SslEnforcementEnum(sslEnforcement),
+		AdministratorLogin:         utils.String(adminLogin),
+		AdministratorLoginPassword: utils.String(adminLoginPassword),
+	}
+
+	if strings.EqualFold(d.Get("create_mode").(string), string(mysql.CreateModePointInTimeRestore)) {
+		// this is checked here rather than during the plan, where these are empty when they aren't known until apply
+		// (e.g. when they're interpolated from another resource)
+		sourceServerId := d.Get("source_server_id").(string)
+		if sourceServerId == "" || d.Get("restore_point_in_time").(string) == "" {
+			return fmt.Errorf("`source_server_id` and `restore_point_in_time` must be specified when `create_mode` is `PointInTimeRestore`")
+		}
+
+		restorePointInTime, err := date.ParseTime(time.RFC3339, d.Get("restore_point_in_time").(string))
+		if err != nil {
+			return fmt.Errorf("`restore_point_in_time` wasn't a valid RFC3339 date: %+v", err)
+		}
+
+		if err := validateMySQLServerRestoreSource(ctx, meta.(*ArmClient), sourceServerId, restorePointInTime); err != nil {
+			return err
+		}
+
+		serverProperties = &mysql.ServerPropertiesForRestore{
+			Version:            mysql.ServerVersion(version),
+			StorageMB:          utils.Int64(int64(storageMB)),
+			SslEnforcement:     mysql.SslEnforcementEnum(sslEnforcement),
+			SourceServerID:     utils.String(sourceServerId),
+			RestorePointInTime: &date.Time{Time: restorePointInTime},
+		}
+	}
+
 	properties := mysql.ServerForCreate{
-		Location: &location,
-		Sku:      sku,
-		Properties: &mysql.ServerPropertiesForDefaultCreate{
-			Version:                    mysql.ServerVersion(version),
-			StorageMB:                  utils.Int64(int64(storageMB)),
-			SslEnforcement:             mysql.SslEnforcementEnum(sslEnforcement),
-			AdministratorLogin:         utils.String(adminLogin),
-			AdministratorLoginPassword: utils.String(adminLoginPassword),
-		},
-		Tags: expandTags(tags),
+		Location:   &location,
+		Sku:        sku,
+		Properties: serverProperties,
+		Tags:       expandTags(tags),
 	}
 
-	future, err := client.CreateOrUpdate(ctx, resourceGroup, name, properties)
+	read, err := createMySQLServer(ctx, client, meta.(*ArmClient).mysqlOperationsLimiter, resourceGroup, name, properties)
 	if err != nil {
 		return err
 	}
 
-	err = future.WaitForCompletion(ctx, client.Client)
+	d.SetId(*read.ID)
+	clearMySQLServerWriteOnlyPassword(d)
+
+	if v, ok := d.GetOk("query_store"); ok {
+		configClient := meta.(*ArmClient).mysqlConfigurationsClient
+		if err := setMySQLServerQueryStore(ctx, configClient, resourceGroup, name, v.([]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if v, ok := d.GetOk("connection_limits"); ok {
+		configClient := meta.(*ArmClient).mysqlConfigurationsClient
+		if err := setMySQLServerConnectionLimits(ctx, configClient, resourceGroup, name, []interface{}{}, v.([]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if v, ok := d.GetOk("audit_log"); ok {
+		configClient := meta.(*ArmClient).mysqlConfigurationsClient
+		if err := setMySQLServerAuditLog(ctx, configClient, resourceGroup, name, v.([]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if v, ok := d.GetOk("ready_delay"); ok {
+		// this has already been validated
+		delay, _ := time.ParseDuration(v.(string))
+		description := fmt.Sprintf("MySQL Server %q (Resource Group %q) to warm up", name, resourceGroup)
+		if err := waitForDelay(ctx, description, delay); err != nil {
+			return err
+		}
+	}
+
+	return resourceArmMySqlServerRead(d, meta)
+}
+
+// createMySQLServer creates the MySQL Server and waits for it to be ready - only holding a slot of the `limiter` until
+// then, so that the configuration updates and `ready_delay` which follow don't hold up other operations
+func createMySQLServer(ctx context.Context, client mysql.ServersClient, limiter *operationLimiter, resourceGroup, name string, properties mysql.ServerForCreate) (mysql.Server, error) {
+	description := fmt.Sprintf("the creation of MySQL Server %q (Resource Group %q)", name, resourceGroup)
+	if err := limiter.acquire(ctx, description); err != nil {
+		return mysql.Server{}, err
+	}
+	defer limiter.release()
+
+	err := retryMySQLServerCreationWhilstPendingDeletion(ctx, name, resourceGroup, mysqlServerPendingDeletionTimeout, mysqlServerPendingDeletionPollInterval, func() error {
+		future, err := client.CreateOrUpdate(ctx, resourceGroup, name, properties)
+		if err != nil {
+			return err
+		}
+
+		return waitForCompletionRetryingOnTransientErrors(ctx, description, &future, client.Client, mysqlServerCreateTimeout)
+	})
 	if err != nil {
-		return err
+		return mysql.Server{}, err
 	}
 
-	read, err := client.Get(ctx, resourceGroup, name)
+	read, err := getMySQLServerAfterCreate(ctx, client, resourceGroup, name, mysqlServerCreateReadTimeout)
 	if err != nil {
-		return err
+		return mysql.Server{}, err
 	}
 	if read.ID == nil {
-		return fmt.Errorf("Cannot read MySQL Server %q (resource group %q) ID", name, resourceGroup)
+		return mysql.Server{}, fmt.Errorf("Cannot read MySQL Server %q (resource group %q) ID", name, resourceGroup)
 	}
 
-	d.SetId(*read.ID)
+	description = fmt.Sprintf("MySQL Server %q (Resource Group %q)", name, resourceGroup)
+	pending := []string{""}
+	target := []string{string(mysql.Ready)}
+	err = waitForProvisioningState(description, pending, target, mysqlServerProvisioningTimeout, func() (string, error) {
+		server, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			return "", err
+		}
 
-	return resourceArmMySqlServerRead(d, meta)
+		if server.ServerProperties == nil {
+			return "", nil
+		}
+
+		return string(server.ServerProperties.UserVisibleState), nil
+	})
+	if err != nil {
+		return mysql.Server{}, err
+	}
+
+	return read, nil
+}
+
+// retryMySQLServerCreationWhilstPendingDeletion calls `create` - retrying it whilst a previously deleted MySQL Server
+// with the same name is pending deletion, until it's been purged or the `timeout` is reached.
+func retryMySQLServerCreationWhilstPendingDeletion(ctx context.Context, name, resourceGroup string, timeout, interval time.Duration, create func() error) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		err := create()
+		if err == nil || !mysqlServerPendingDeletion(err) {
+			return err
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("Error creating MySQL Server %q (Resource Group %q): a previously deleted MySQL Server with the same name is pending deletion and wasn't purged within %s. Either purge the deleted MySQL Server (or wait for it to be purged) and try again, or use a different `name`: %s", name, resourceGroup, timeout, formatARMError(err))
+		}
+
+		description := fmt.Sprintf("the previously deleted MySQL Server %q (Resource Group %q) to be purged", name, resourceGroup)
+		if err := waitForDelay(ctx, description, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// mysqlServerPendingDeletion returns whether the error is because the name is held by a deleted MySQL Server which
+// hasn't been purged yet
+func mysqlServerPendingDeletion(err error) bool {
+	serviceError := armServiceError(err)
+	return serviceError != nil && serviceError.Code == mysqlServerPendingDeletionErrorCode
+}
+
+// validateMySQLServerRestoreSource ensures the `restore_point_in_time` is within the backup retention period of the
+// source MySQL Server, which is determined by its Performance Tier - so that this fails before the Server is created.
+func validateMySQLServerRestoreSource(ctx context.Context, client *ArmClient, sourceServerId string, restorePointInTime time.Time) error {
+	id, err := parseAzureResourceID(sourceServerId)
+	if err != nil {
+		return fmt.Errorf("Error parsing `source_server_id` %q: %+v", sourceServerId, err)
+	}
+
+	resourceGroup := id.ResourceGroup
+	name := id.Path["servers"]
+
+	source, err := client.mysqlServersClient.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving the source MySQL Server %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+	}
+
+	if source.Location == nil || source.Sku == nil {
+		return fmt.Errorf("Error retrieving the source MySQL Server %q (Resource Group %q): `location` or `sku` was nil", name, resourceGroup)
+	}
+
+	tiers, err := client.mysqlPerformanceTiersClient.List(ctx, *source.Location)
+	if err != nil {
+		return fmt.Errorf("Error listing the MySQL Performance Tiers in %q: %s", *source.Location, formatARMError(err))
+	}
+
+	retentionDays, err := mysqlServerBackupRetentionDays(tiers.Value, string(source.Sku.Tier))
+	if err != nil {
+		return err
+	}
+
+	return validateMySQLServerRestorePointInTime(restorePointInTime, time.Now(), retentionDays)
+}
+
+// mysqlServerBackupRetentionDays returns the number of days backups are retained for the specified Performance Tier
+func mysqlServerBackupRetentionDays(tiers *[]mysql.PerformanceTierProperties, tier string) (int, error) {
+	if tiers != nil {
+		for _, v := range *tiers {
+			if v.ID == nil || !strings.EqualFold(*v.ID, tier) {
+				continue
+			}
+
+			if v.BackupRetentionDays == nil {
+				break
+			}
+
+			return int(*v.BackupRetentionDays), nil
+		}
+	}
+
+	return 0, fmt.Errorf("Unable to determine the backup retention period for the MySQL Performance Tier %q", tier)
+}
+
+func validateMySQLServerRestorePointInTime(restorePointInTime time.Time, now time.Time, retentionDays int) error {
+	earliest := now.Add(-time.Duration(retentionDays) * 24 * time.Hour)
+
+	if restorePointInTime.After(now) {
+		return fmt.Errorf("`restore_point_in_time` (%s) can't be in the future", restorePointInTime.Format(time.RFC3339))
+	}
+
+	if restorePointInTime.Before(earliest) {
+		return fmt.Errorf("`restore_point_in_time` (%s) must be within the source MySQL Server's backup retention period of %d days (after %s)", restorePointInTime.Format(time.RFC3339), retentionDays, earliest.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// getMySQLServerAfterCreate retrieves the newly created MySQL Server - since it can take a while for the
+// Server to be visible after creation 404's are retried until the `timeout` is reached, as are requests which fail
+// temporarily (see `armErrorIsRetriable`).
+func getMySQLServerAfterCreate(ctx context.Context, client mysql.ServersClient, resourceGroup, name string, timeout time.Duration) (mysql.Server, error) {
+	var server mysql.Server
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		var err error
+		server, err = client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(server.Response) {
+				log.Printf("[DEBUG] MySQL Server %q (resource group %q) isn't available yet - retrying", name, resourceGroup)
+				return resource.RetryableError(err)
+			}
+
+			if armErrorIsRetriable(err) {
+				log.Printf("[DEBUG] Retrieving MySQL Server %q (resource group %q) failed temporarily - retrying: %+v", name, resourceGroup, err)
+				return resource.RetryableError(err)
+			}
+
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	return server, err
 }
 
 func resourceArmMySqlServerUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -223,14 +688,18 @@ func resourceArmMySqlServerUpdate(d *schema.ResourceData, meta interface{}) erro
 	properties := mysql.ServerUpdateParameters{
 		Sku: sku,
 		ServerUpdateParametersProperties: &mysql.ServerUpdateParametersProperties{
-			SslEnforcement:             mysql.SslEnforcementEnum(sslEnforcement),
-			StorageMB:                  utils.Int64(int64(storageMB)),
-			Version:                    mysql.ServerVersion(version),
-			AdministratorLoginPassword: utils.String(adminLoginPassword),
+			SslEnforcement: mysql.SslEnforcementEnum(sslEnforcement),
+			StorageMB:      utils.Int64(int64(storageMB)),
+			Version:        mysql.ServerVersion(version),
 		},
 		Tags: expandTags(tags),
 	}
 
+	// a write-only password is only available when `password_wo_version` has changed
+	if adminLoginPassword != "" {
+		properties.ServerUpdateParametersProperties.AdministratorLoginPassword = utils.String(adminLoginPassword)
+	}
+
 	future, err := client.Update(ctx, resourceGroup, name, properties)
 	if err != nil {
 		return err
@@ -250,6 +719,29 @@ func resourceArmMySqlServerUpdate(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	d.SetId(*read.ID)
+	clearMySQLServerWriteOnlyPassword(d)
+
+	if d.HasChange("query_store") {
+		configClient := meta.(*ArmClient).mysqlConfigurationsClient
+		if err := setMySQLServerQueryStore(ctx, configClient, resourceGroup, name, d.Get("query_store").([]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("connection_limits") {
+		configClient := meta.(*ArmClient).mysqlConfigurationsClient
+		old, new := d.GetChange("connection_limits")
+		if err := setMySQLServerConnectionLimits(ctx, configClient, resourceGroup, name, old.([]interface{}), new.([]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("audit_log") {
+		configClient := meta.(*ArmClient).mysqlConfigurationsClient
+		if err := setMySQLServerAuditLog(ctx, configClient, resourceGroup, name, d.Get("audit_log").([]interface{})); err != nil {
+			return err
+		}
+	}
 
 	return resourceArmMySqlServerRead(d, meta)
 }
@@ -271,7 +763,10 @@ func resourceArmMySqlServerRead(d *schema.ResourceData, meta interface{}) error
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("Error making Read request on Azure MySQL Server %q (Resource Group %q): %+v", name, resourceGroup, err)
+		if readWasForbidden(meta.(*ArmClient), resp.Response, fmt.Sprintf("MySQL Server %q (Resource Group %q)", name, resourceGroup)) {
+			return nil
+		}
+		return fmt.Errorf("Error making Read request on Azure MySQL Server %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
 	}
 
 	d.Set("name", resp.Name)
@@ -281,6 +776,11 @@ func resourceArmMySqlServerRead(d *schema.ResourceData, meta interface{}) error
 		d.Set("location", azureRMNormalizeLocation(*location))
 	}
 
+	// the API doesn't return how the Server was created, so default this for Servers which have been imported
+	if _, ok := d.GetOk("create_mode"); !ok {
+		d.Set("create_mode", string(mysql.CreateModeDefault))
+	}
+
 	d.Set("administrator_login", resp.AdministratorLogin)
 	d.Set("version", string(resp.Version))
 	d.Set("storage_mb", int(*resp.StorageMB))
@@ -290,11 +790,42 @@ func resourceArmMySqlServerRead(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	queryStore, err := flattenMySQLServerQueryStore(ctx, meta.(*ArmClient).mysqlConfigurationsClient, resourceGroup, name, d.Get("query_store").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if err := d.Set("query_store", queryStore); err != nil {
+		return fmt.Errorf("Error setting `query_store`: %+v", err)
+	}
+
+	connectionLimits, err := flattenMySQLServerConnectionLimits(ctx, meta.(*ArmClient).mysqlConfigurationsClient, resourceGroup, name, d.Get("connection_limits").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if err := d.Set("connection_limits", connectionLimits); err != nil {
+		return fmt.Errorf("Error setting `connection_limits`: %+v", err)
+	}
+
+	auditLog, err := flattenMySQLServerAuditLog(ctx, meta.(*ArmClient).mysqlConfigurationsClient, resourceGroup, name, d.Get("audit_log").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if err := d.Set("audit_log", auditLog); err != nil {
+		return fmt.Errorf("Error setting `audit_log`: %+v", err)
+	}
+
+	flattenAndSetTagsIgnoringSystemTags(d, resp.Tags, meta.(*ArmClient).ignoreSystemTags)
 
 	// Computed
 	d.Set("fqdn", resp.FullyQualifiedDomainName)
 
+	if fqdn := resp.FullyQualifiedDomainName; fqdn != nil && resp.AdministratorLogin != nil {
+		connectionStrings := mysqlServerConnectionStrings(*fqdn, name, *resp.AdministratorLogin)
+		for k, v := range connectionStrings {
+			d.Set(k, v)
+		}
+	}
+
 	return nil
 }
 
@@ -309,6 +840,12 @@ func resourceArmMySqlServerDelete(d *schema.ResourceData, meta interface{}) erro
 	resourceGroup := id.ResourceGroup
 	name := id.Path["servers"]
 
+	limiter := meta.(*ArmClient).mysqlOperationsLimiter
+	if err := limiter.acquire(ctx, fmt.Sprintf("the deletion of MySQL Server %q (Resource Group %q)", name, resourceGroup)); err != nil {
+		return err
+	}
+	defer limiter.release()
+
 	future, err := client.Delete(ctx, resourceGroup, name)
 	if err != nil {
 		return err
@@ -348,3 +885,264 @@ func flattenMySQLServerSku(d *schema.ResourceData, resp *mysql.Sku) []interface{
 	sku := []interface{}{values}
 	return sku
 }
+
+// setMySQLServerQueryStore updates the Server Configurations which make up the `query_store` block - when the
+// block is removed these are reset to their default values.
+func setMySQLServerQueryStore(ctx context.Context, client mysql.ConfigurationsClient, resourceGroup, serverName string, input []interface{}) error {
+	var queryStore map[string]interface{}
+	if len(input) > 0 && input[0] != nil {
+		queryStore = input[0].(map[string]interface{})
+	}
+
+	for _, v := range mysqlServerQueryStoreConfigurations {
+		var value *string
+		if queryStore != nil {
+			value = utils.String(queryStore[v.field].(string))
+		}
+
+		if err := setMySQLServerConfiguration(ctx, client, resourceGroup, serverName, v.configuration, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setMySQLServerConfiguration sets the value of a Server Configuration, or resets it to the default when `value` is nil
+func setMySQLServerConfiguration(ctx context.Context, client mysql.ConfigurationsClient, resourceGroup, serverName, name string, value *string) error {
+	if value == nil {
+		resp, err := client.Get(ctx, resourceGroup, serverName, name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving MySQL Configuration %q (MySQL Server %q / Resource Group %q): %s", name, serverName, resourceGroup, formatARMError(err))
+		}
+
+		if resp.ConfigurationProperties != nil {
+			value = resp.DefaultValue
+		}
+	}
+
+	log.Printf("[DEBUG] Setting MySQL Configuration %q (MySQL Server %q / Resource Group %q)", name, serverName, resourceGroup)
+	properties := mysql.Configuration{
+		ConfigurationProperties: &mysql.ConfigurationProperties{
+			Value: value,
+		},
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, serverName, name, properties)
+	if err != nil {
+		return fmt.Errorf("Error setting MySQL Configuration %q (MySQL Server %q / Resource Group %q): %s", name, serverName, resourceGroup, formatARMError(err))
+	}
+
+	err = future.WaitForCompletion(ctx, client.Client)
+	if err != nil {
+		return fmt.Errorf("Error waiting for MySQL Configuration %q (MySQL Server %q / Resource Group %q) to be set: %s", name, serverName, resourceGroup, formatARMError(err))
+	}
+
+	return nil
+}
+
+// flattenMySQLServerQueryStore returns the `query_store` block - which is only read when it's `configured`, so that
+// these Server Configurations can be managed using the `azurerm_mysql_configuration` resource instead.
+func flattenMySQLServerQueryStore(ctx context.Context, client mysql.ConfigurationsClient, resourceGroup, serverName string, configured []interface{}) ([]interface{}, error) {
+	if len(configured) == 0 {
+		return []interface{}{}, nil
+	}
+
+	queryStore, _, err := getMySQLServerQueryStore(ctx, client, resourceGroup, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{queryStore}, nil
+}
+
+// getMySQLServerQueryStore returns the values of the Server Configurations for the Query Store, and whether these are
+// all using their default values - which is the same as not configuring the Query Store.
+func getMySQLServerQueryStore(ctx context.Context, client mysql.ConfigurationsClient, resourceGroup, serverName string) (map[string]interface{}, bool, error) {
+	queryStore := make(map[string]interface{})
+	isDefault := true
+
+	for _, v := range mysqlServerQueryStoreConfigurations {
+		resp, err := client.Get(ctx, resourceGroup, serverName, v.configuration)
+		if err != nil {
+			return nil, false, fmt.Errorf("Error retrieving MySQL Configuration %q (MySQL Server %q / Resource Group %q): %s", v.configuration, serverName, resourceGroup, formatARMError(err))
+		}
+
+		value := ""
+		if props := resp.ConfigurationProperties; props != nil {
+			if props.Value != nil {
+				value = *props.Value
+			}
+
+			if props.DefaultValue == nil || !strings.EqualFold(value, *props.DefaultValue) {
+				isDefault = false
+			}
+		}
+
+		queryStore[v.field] = value
+	}
+
+	return queryStore, isDefault, nil
+}
+
+// setMySQLServerConnectionLimits updates the Server Configurations within the `connection_limits` block which have changed - any
+// which have been removed (or the entire block) are reset to their default values.
+func setMySQLServerConnectionLimits(ctx context.Context, client mysql.ConfigurationsClient, resourceGroup, serverName string, old []interface{}, new []interface{}) error {
+	oldValues := mysqlServerConnectionLimitsValues(old)
+	newValues := mysqlServerConnectionLimitsValues(new)
+
+	for _, v := range mysqlServerConnectionLimitsConfigurations {
+		if oldValues[v.field] == newValues[v.field] {
+			continue
+		}
+
+		var value *string
+		if newValues[v.field] != 0 {
+			value = utils.String(strconv.Itoa(newValues[v.field]))
+		}
+
+		if err := setMySQLServerConfiguration(ctx, client, resourceGroup, serverName, v.configuration, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func mysqlServerConnectionLimitsValues(input []interface{}) map[string]int {
+	output := make(map[string]int)
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	connectionLimits := input[0].(map[string]interface{})
+	for _, v := range mysqlServerConnectionLimitsConfigurations {
+		if value, ok := connectionLimits[v.field].(int); ok {
+			output[v.field] = value
+		}
+	}
+
+	return output
+}
+
+// flattenMySQLServerConnectionLimits returns the `connection_limits` block - which includes only the Server Configurations
+// which have been `configured` (so that the others can be managed using the `azurerm_mysql_configuration` resource), and is
+// omitted when the block isn't configured.
+func flattenMySQLServerConnectionLimits(ctx context.Context, client mysql.ConfigurationsClient, resourceGroup, serverName string, configured []interface{}) ([]interface{}, error) {
+	if len(configured) == 0 || configured[0] == nil {
+		return []interface{}{}, nil
+	}
+
+	configuredValues := mysqlServerConnectionLimitsValues(configured)
+	connectionLimits := make(map[string]interface{})
+
+	for _, v := range mysqlServerConnectionLimitsConfigurations {
+		if configuredValues[v.field] == 0 {
+			connectionLimits[v.field] = 0
+			continue
+		}
+
+		resp, err := client.Get(ctx, resourceGroup, serverName, v.configuration)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving MySQL Configuration %q (MySQL Server %q / Resource Group %q): %s", v.configuration, serverName, resourceGroup, formatARMError(err))
+		}
+
+		value := 0
+		if props := resp.ConfigurationProperties; props != nil && props.Value != nil {
+			value, err = strconv.Atoi(*props.Value)
+			if err != nil {
+				return nil, fmt.Errorf("Error parsing MySQL Configuration %q (MySQL Server %q / Resource Group %q) value %q: %+v", v.configuration, serverName, resourceGroup, *props.Value, err)
+			}
+		}
+
+		connectionLimits[v.field] = value
+	}
+
+	return []interface{}{connectionLimits}, nil
+}
+
+// setMySQLServerAuditLog sets the Server Configurations for the `audit_log` block - when the block is removed these
+// are reset to their default values, as are the events when none are specified.
+func setMySQLServerAuditLog(ctx context.Context, client mysql.ConfigurationsClient, resourceGroup, serverName string, input []interface{}) error {
+	var enabled, events *string
+
+	if len(input) > 0 && input[0] != nil {
+		auditLog := input[0].(map[string]interface{})
+
+		enabled = utils.String("OFF")
+		if auditLog["enabled"].(bool) {
+			enabled = utils.String("ON")
+		}
+
+		if v, ok := auditLog["events"].(*schema.Set); ok && v.Len() > 0 {
+			values := make([]string, 0)
+			for _, event := range v.List() {
+				values = append(values, event.(string))
+			}
+			sort.Strings(values)
+			events = utils.String(strings.Join(values, ","))
+		}
+	}
+
+	// the events are set first, so that only these are logged once the Audit Log is enabled
+	if err := setMySQLServerConfiguration(ctx, client, resourceGroup, serverName, mysqlServerAuditLogEventsConfiguration, events); err != nil {
+		return err
+	}
+
+	return setMySQLServerConfiguration(ctx, client, resourceGroup, serverName, mysqlServerAuditLogEnabledConfiguration, enabled)
+}
+
+// flattenMySQLServerAuditLog returns the `audit_log` block - which is only read when it's `configured`, so that these
+// Server Configurations can be managed using the `azurerm_mysql_configuration` resource instead. The events are only
+// included when they've been configured.
+func flattenMySQLServerAuditLog(ctx context.Context, client mysql.ConfigurationsClient, resourceGroup, serverName string, configured []interface{}) ([]interface{}, error) {
+	if len(configured) == 0 || configured[0] == nil {
+		return []interface{}{}, nil
+	}
+
+	resp, err := client.Get(ctx, resourceGroup, serverName, mysqlServerAuditLogEnabledConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving MySQL Configuration %q (MySQL Server %q / Resource Group %q): %s", mysqlServerAuditLogEnabledConfiguration, serverName, resourceGroup, formatARMError(err))
+	}
+
+	enabled := false
+	if props := resp.ConfigurationProperties; props != nil && props.Value != nil {
+		enabled = strings.EqualFold(*props.Value, "ON")
+	}
+
+	events := make([]interface{}, 0)
+	if v, ok := configured[0].(map[string]interface{})["events"].(*schema.Set); ok && v.Len() > 0 {
+		resp, err := client.Get(ctx, resourceGroup, serverName, mysqlServerAuditLogEventsConfiguration)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving MySQL Configuration %q (MySQL Server %q / Resource Group %q): %s", mysqlServerAuditLogEventsConfiguration, serverName, resourceGroup, formatARMError(err))
+		}
+
+		if props := resp.ConfigurationProperties; props != nil && props.Value != nil {
+			for _, event := range strings.Split(*props.Value, ",") {
+				if event = strings.TrimSpace(event); event != "" {
+					events = append(events, strings.ToUpper(event))
+				}
+			}
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"enabled": enabled,
+			"events":  schema.NewSet(schema.HashString, events),
+		},
+	}, nil
+}
+
+// mysqlServerConnectionStrings builds the connection strings exported for a MySQL Server.
+// The password is never embedded - a placeholder is used instead so these are safe to output.
+func mysqlServerConnectionStrings(fqdn string, serverName string, administratorLogin string) map[string]string {
+	// Azure Database for MySQL requires the login to be qualified with the server name
+	user := fmt.Sprintf("%s@%s", administratorLogin, serverName)
+
+	return map[string]string{
+		"connection_string":         fmt.Sprintf("%s:%d", fqdn, mysqlServerPort),
+		"ado_net_connection_string": fmt.Sprintf("Server=%s; Port=%d; Database={your_database}; Uid=%s; Pwd={your_password}; SslMode=Preferred;", fqdn, mysqlServerPort, user),
+		"jdbc_connection_string":    fmt.Sprintf("jdbc:mysql://%s:%d/{your_database}?user=%s&password={your_password}&useSSL=true&requireSSL=false", fqdn, mysqlServerPort, user),
+	}
+}
@@ -1,15 +1,1271 @@
 package azurerm
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-04-30-preview/mysql"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+func init() {
+	resource.AddTestSweepers("azurerm_mysql_server", &resource.Sweeper{
+		Name: "azurerm_mysql_server",
+		F:    testSweepMySQLServers,
+	})
+}
+
+func testSweepMySQLServers(region string) error {
+	armClient, err := buildConfigForSweepers()
+	if err != nil {
+		return err
+	}
+
+	client := armClient.mysqlServersClient
+	ctx := armClient.StopContext
+
+	log.Printf("Retrieving the MySQL Servers..")
+	results, err := client.List(ctx)
+	if err != nil {
+		return fmt.Errorf("Error Listing on MySQL Servers: %+v", err)
+	}
+
+	if results.Value == nil {
+		return nil
+	}
+
+	for _, server := range *results.Value {
+		if server.Name == nil || server.Location == nil || server.ID == nil {
+			continue
+		}
+
+		if !shouldSweepAcceptanceTestResource(*server.Name, *server.Location, region) {
+			continue
+		}
+
+		resourceId, err := parseAzureResourceID(*server.ID)
+		if err != nil {
+			return err
+		}
+
+		resourceGroup := resourceId.ResourceGroup
+		name := resourceId.Path["servers"]
+
+		log.Printf("Deleting MySQL Server %q in Resource Group %q", name, resourceGroup)
+		future, err := client.Delete(ctx, resourceGroup, name)
+		if err != nil {
+			return fmt.Errorf("Error deleting MySQL Server %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		err = future.WaitForCompletion(ctx, client.Client)
+		if err != nil {
+			return fmt.Errorf("Error waiting for deletion of MySQL Server %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func TestMySQLServerConnectionStrings(t *testing.T) {
+	connectionStrings := mysqlServerConnectionStrings("acctestmysql.mysql.database.azure.com", "acctestmysql", "mysqladmin")
+
+	expected := map[string]string{
+		"connection_string":         "acctestmysql.mysql.database.azure.com:3306",
+		"ado_net_connection_string": "Server=acctestmysql.mysql.database.azure.com; Port=3306; Database={your_database}; Uid=mysqladmin@acctestmysql; Pwd={your_password}; SslMode=Preferred;",
+		"jdbc_connection_string":    "jdbc:mysql://acctestmysql.mysql.database.azure.com:3306/{your_database}?user=mysqladmin@acctestmysql&password={your_password}&useSSL=true&requireSSL=false",
+	}
+
+	if len(connectionStrings) != len(expected) {
+		t.Fatalf("Expected %d connection strings but got %d", len(expected), len(connectionStrings))
+	}
+
+	for key, value := range expected {
+		if connectionStrings[key] != value {
+			t.Fatalf("Expected %q to be %q but got %q", key, value, connectionStrings[key])
+		}
+	}
+}
+
+func TestMySQLServerVersion(t *testing.T) {
+	versionSchema := resourceArmMySqlServer().Schema["version"]
+
+	if !versionSchema.ForceNew {
+		t.Fatalf("Expected changing the `version` to force a new resource, since major version upgrades aren't supported in-place")
+	}
+
+	testCases := []struct {
+		input       string
+		shouldError bool
+	}{
+		{string(mysql.FiveFullStopSix), false},
+		{string(mysql.FiveFullStopSeven), false},
+		{"5.5", true},
+		{"8.0", true},
+		{"5", true},
+		{"", true},
+	}
+
+	for _, test := range testCases {
+		_, es := versionSchema.ValidateFunc(test.input, "version")
+
+		if test.shouldError && len(es) == 0 {
+			t.Fatalf("Expected validating version %q to fail", test.input)
+		}
+
+		if !test.shouldError && len(es) > 0 {
+			t.Fatalf("Expected validating version %q not to fail: %+v", test.input, es)
+		}
+	}
+}
+
+func TestValidateMySQLServerStorageMBChange(t *testing.T) {
+	testCases := []struct {
+		old         int
+		new         int
+		shouldError bool
+	}{
+		{51200, 51200, false},
+		{51200, 179200, false},
+		{128000, 1024000, false},
+		{179200, 51200, true},
+		{1024000, 128000, true},
+	}
+
+	for _, test := range testCases {
+		err := validateMySQLServerStorageMBChange(test.old, test.new)
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected an error changing `storage_mb` from %d to %d but didn't get one", test.old, test.new)
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected no error changing `storage_mb` from %d to %d but got: %+v", test.old, test.new, err)
+		}
+	}
+}
+
+func TestResourceArmMySqlServerDiff_pointInTimeRestoreUnknownSource(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":                  "server1",
+		"location":              "westeurope",
+		"resource_group_name":   "group1",
+		"administrator_login":   "acctestun",
+		"version":               "5.7",
+		"storage_mb":            51200,
+		"ssl_enforcement":       "Enabled",
+		"create_mode":           "PointInTimeRestore",
+		"source_server_id":      config.UnknownVariableValue,
+		"restore_point_in_time": config.UnknownVariableValue,
+		"sku": []interface{}{
+			map[string]interface{}{
+				"name":     "MYSQLB50",
+				"capacity": 50,
+				"tier":     "Basic",
+			},
+		},
+	}
+
+	if _, err := resourceArmMySqlServer().Diff(nil, terraform.NewResourceConfig(config.TestRawConfig(t, raw)), &ArmClient{}); err != nil {
+		t.Fatalf("Expected no error planning a restore from a source which isn't known until apply but got: %+v", err)
+	}
+}
+
+func TestResourceArmMySqlServerDiff_storageMB(t *testing.T) {
+	r := resourceArmMySqlServer()
+
+	// increasing the storage updates the MySQL Server in-place
+	for _, storageMB := range []int{307200, 947200} {
+		state := &terraform.InstanceState{
+			ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1",
+			Attributes: map[string]string{
+				"name":                         "server1",
+				"location":                     "westeurope",
+				"resource_group_name":          "group1",
+				"create_mode":                  "Default",
+				"administrator_login":          "acctestun",
+				"administrator_login_password": "H@Sh1CoR3!",
+				"version":                      "5.7",
+				"storage_mb":                   "179200",
+				"ssl_enforcement":              "Enabled",
+			},
+		}
+
+		raw := map[string]interface{}{
+			"name":                         "server1",
+			"location":                     "westeurope",
+			"resource_group_name":          "group1",
+			"administrator_login":          "acctestun",
+			"administrator_login_password": "H@Sh1CoR3!",
+			"version":                      "5.7",
+			"storage_mb":                   storageMB,
+			"ssl_enforcement":              "Enabled",
+			"sku": []interface{}{
+				map[string]interface{}{
+					"name":     "MYSQLB50",
+					"capacity": 50,
+					"tier":     "Basic",
+				},
+			},
+		}
+
+		diff, err := r.Diff(state, terraform.NewResourceConfig(config.TestRawConfig(t, raw)), &ArmClient{})
+		if err != nil {
+			t.Fatalf("Expected no error increasing `storage_mb` from 179200 to %d but got: %+v", storageMB, err)
+		}
+
+		if diff.RequiresNew() {
+			t.Fatalf("Expected increasing `storage_mb` from 179200 to %d to be updated in-place but got: %+v", storageMB, diff)
+		}
+
+		if attr, ok := diff.Attributes["storage_mb"]; !ok || attr.New != strconv.Itoa(storageMB) {
+			t.Fatalf("Expected a diff for `storage_mb` to %d but got %+v", storageMB, diff.Attributes["storage_mb"])
+		}
+	}
+
+	// decreasing the storage fails during the plan, rather than recreating the MySQL Server
+	state := &terraform.InstanceState{
+		ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1",
+		Attributes: map[string]string{
+			"name":                "server1",
+			"location":            "westeurope",
+			"resource_group_name": "group1",
+			"storage_mb":          "307200",
+		},
+	}
+	raw := map[string]interface{}{
+		"name":                         "server1",
+		"location":                     "westeurope",
+		"resource_group_name":          "group1",
+		"administrator_login":          "acctestun",
+		"administrator_login_password": "H@Sh1CoR3!",
+		"version":                      "5.7",
+		"storage_mb":                   179200,
+		"ssl_enforcement":              "Enabled",
+		"sku": []interface{}{
+			map[string]interface{}{
+				"name":     "MYSQLB50",
+				"capacity": 50,
+				"tier":     "Basic",
+			},
+		},
+	}
+	if _, err := r.Diff(state, terraform.NewResourceConfig(config.TestRawConfig(t, raw)), &ArmClient{}); err == nil {
+		t.Fatalf("Expected an error decreasing `storage_mb` from 307200 to 179200 but didn't get one")
+	}
+}
+
+func TestSetMySQLServerQueryStore(t *testing.T) {
+	testCases := []struct {
+		input          []interface{}
+		expectedValues []string
+		expectedGets   int
+	}{
+		{
+			input: []interface{}{
+				map[string]interface{}{
+					"capture_mode":               "ALL",
+					"wait_sampling_capture_mode": "NONE",
+				},
+			},
+			expectedValues: []string{"ALL", "NONE"},
+			expectedGets:   0,
+		},
+		{
+			// removing the block resets the configurations to their defaults
+			input:          []interface{}{},
+			expectedValues: []string{"DEFAULT", "DEFAULT"},
+			expectedGets:   2,
+		},
+	}
+
+	for _, test := range testCases {
+		gets := 0
+		values := make([]string, 0)
+		configurations := make([]string, 0)
+
+		client := mysql.NewConfigurationsClient("00000000-0000-0000-0000-000000000000")
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			body := `{"properties": {"value": "NONE", "defaultValue": "DEFAULT"}}`
+
+			if r.Method == http.MethodGet {
+				gets++
+			} else {
+				var configuration mysql.Configuration
+				if err := json.NewDecoder(r.Body).Decode(&configuration); err != nil {
+					t.Fatalf("Error decoding request: %+v", err)
+				}
+
+				segments := strings.Split(r.URL.Path, "/")
+				configurations = append(configurations, segments[len(segments)-1])
+				values = append(values, *configuration.Value)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    r,
+			}, nil
+		})
+
+		err := setMySQLServerQueryStore(context.Background(), client, "group1", "server1", test.input)
+		if err != nil {
+			t.Fatalf("Expected no error setting the Query Store but got: %+v", err)
+		}
+
+		expectedConfigurations := []string{"query_store_capture_mode", "query_store_wait_sampling_capture_mode"}
+		if !reflect.DeepEqual(configurations, expectedConfigurations) {
+			t.Fatalf("Expected the configurations %v to be set but got %v", expectedConfigurations, configurations)
+		}
+
+		if !reflect.DeepEqual(values, test.expectedValues) {
+			t.Fatalf("Expected the values %v but got %v", test.expectedValues, values)
+		}
+
+		if gets != test.expectedGets {
+			t.Fatalf("Expected %d GET requests but got %d", test.expectedGets, gets)
+		}
+	}
+}
+
+func TestFlattenMySQLServerQueryStore(t *testing.T) {
+	configured := []interface{}{
+		map[string]interface{}{
+			"capture_mode":               "NONE",
+			"wait_sampling_capture_mode": "NONE",
+		},
+	}
+
+	testCases := []struct {
+		responses         map[string]string
+		configured        []interface{}
+		expected          []interface{}
+		expectedGets      int
+		expectedIsDefault bool
+	}{
+		{
+			// when the block isn't configured the Server Configurations aren't read, since they may be managed elsewhere
+			responses: map[string]string{
+				"query_store_capture_mode":               `{"properties": {"value": "ALL", "defaultValue": "NONE"}}`,
+				"query_store_wait_sampling_capture_mode": `{"properties": {"value": "ALL", "defaultValue": "NONE"}}`,
+			},
+			configured:        []interface{}{},
+			expected:          []interface{}{},
+			expectedGets:      0,
+			expectedIsDefault: false,
+		},
+		{
+			responses: map[string]string{
+				"query_store_capture_mode":               `{"properties": {"value": "NONE", "defaultValue": "NONE"}}`,
+				"query_store_wait_sampling_capture_mode": `{"properties": {"value": "none", "defaultValue": "NONE"}}`,
+			},
+			configured: configured,
+			expected: []interface{}{
+				map[string]interface{}{
+					"capture_mode":               "NONE",
+					"wait_sampling_capture_mode": "none",
+				},
+			},
+			expectedGets:      2,
+			expectedIsDefault: true,
+		},
+		{
+			responses: map[string]string{
+				"query_store_capture_mode":               `{"properties": {"value": "ALL", "defaultValue": "NONE"}}`,
+				"query_store_wait_sampling_capture_mode": `{"properties": {"value": "NONE", "defaultValue": "NONE"}}`,
+			},
+			configured: configured,
+			expected: []interface{}{
+				map[string]interface{}{
+					"capture_mode":               "ALL",
+					"wait_sampling_capture_mode": "NONE",
+				},
+			},
+			expectedGets:      2,
+			expectedIsDefault: false,
+		},
+	}
+
+	for _, test := range testCases {
+		gets := 0
+		client := mysql.NewConfigurationsClient("00000000-0000-0000-0000-000000000000")
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			gets++
+			segments := strings.Split(r.URL.Path, "/")
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(test.responses[segments[len(segments)-1]])),
+				Request:    r,
+			}, nil
+		})
+
+		actual, err := flattenMySQLServerQueryStore(context.Background(), client, "group1", "server1", test.configured)
+		if err != nil {
+			t.Fatalf("Expected no error flattening the Query Store but got: %+v", err)
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Fatalf("Expected %+v but got %+v", test.expected, actual)
+		}
+
+		if gets != test.expectedGets {
+			t.Fatalf("Expected %d GET requests but got %d", test.expectedGets, gets)
+		}
+
+		// the Data Source omits the Query Store when it's using its default settings
+		if _, isDefault, err := getMySQLServerQueryStore(context.Background(), client, "group1", "server1"); err != nil || isDefault != test.expectedIsDefault {
+			t.Fatalf("Expected the Query Store default to be %t but got %t: %+v", test.expectedIsDefault, isDefault, err)
+		}
+	}
+}
+
+func TestSetMySQLServerConnectionLimits(t *testing.T) {
+	testCases := []struct {
+		old                    []interface{}
+		new                    []interface{}
+		expectedConfigurations []string
+		expectedValues         []string
+		expectedGets           int
+	}{
+		{
+			// only the fields which are specified are set on creation
+			old: []interface{}{},
+			new: []interface{}{
+				map[string]interface{}{
+					"max_connections":     500,
+					"wait_timeout":        0,
+					"interactive_timeout": 0,
+				},
+			},
+			expectedConfigurations: []string{"max_connections"},
+			expectedValues:         []string{"500"},
+			expectedGets:           0,
+		},
+		{
+			// removing a field resets it to the default
+			old: []interface{}{
+				map[string]interface{}{
+					"max_connections":     500,
+					"wait_timeout":        600,
+					"interactive_timeout": 0,
+				},
+			},
+			new: []interface{}{
+				map[string]interface{}{
+					"max_connections":     500,
+					"wait_timeout":        0,
+					"interactive_timeout": 900,
+				},
+			},
+			expectedConfigurations: []string{"wait_timeout", "interactive_timeout"},
+			expectedValues:         []string{"DEFAULT", "900"},
+			expectedGets:           1,
+		},
+		{
+			// removing the block resets the configurations to their defaults
+			old: []interface{}{
+				map[string]interface{}{
+					"max_connections":     500,
+					"wait_timeout":        600,
+					"interactive_timeout": 900,
+				},
+			},
+			new:                    []interface{}{},
+			expectedConfigurations: []string{"max_connections", "wait_timeout", "interactive_timeout"},
+			expectedValues:         []string{"DEFAULT", "DEFAULT", "DEFAULT"},
+			expectedGets:           3,
+		},
+	}
+
+	for _, test := range testCases {
+		gets := 0
+		values := make([]string, 0)
+		configurations := make([]string, 0)
+
+		client := mysql.NewConfigurationsClient("00000000-0000-0000-0000-000000000000")
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			body := `{"properties": {"value": "100", "defaultValue": "DEFAULT"}}`
+
+			if r.Method == http.MethodGet {
+				gets++
+			} else {
+				var configuration mysql.Configuration
+				if err := json.NewDecoder(r.Body).Decode(&configuration); err != nil {
+					t.Fatalf("Error decoding request: %+v", err)
+				}
+
+				segments := strings.Split(r.URL.Path, "/")
+				configurations = append(configurations, segments[len(segments)-1])
+				values = append(values, *configuration.Value)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    r,
+			}, nil
+		})
+
+		err := setMySQLServerConnectionLimits(context.Background(), client, "group1", "server1", test.old, test.new)
+		if err != nil {
+			t.Fatalf("Expected no error setting the connection limits but got: %+v", err)
+		}
+
+		if !reflect.DeepEqual(configurations, test.expectedConfigurations) {
+			t.Fatalf("Expected the configurations %v to be set but got %v", test.expectedConfigurations, configurations)
+		}
+
+		if !reflect.DeepEqual(values, test.expectedValues) {
+			t.Fatalf("Expected the values %v but got %v", test.expectedValues, values)
+		}
+
+		if gets != test.expectedGets {
+			t.Fatalf("Expected %d GET requests but got %d", test.expectedGets, gets)
+		}
+	}
+}
+
+func TestFlattenMySQLServerConnectionLimits(t *testing.T) {
+	// values which have been changed outside of Terraform (e.g. by the `azurerm_mysql_configuration` resource)
+	responses := map[string]string{
+		"max_connections":     `{"properties": {"value": "300", "defaultValue": "300"}}`,
+		"wait_timeout":        `{"properties": {"value": "600", "defaultValue": "120"}}`,
+		"interactive_timeout": `{"properties": {"value": "28800", "defaultValue": "28800"}}`,
+	}
+
+	testCases := []struct {
+		configured   []interface{}
+		expected     []interface{}
+		expectedGets int
+	}{
+		{
+			// the Server Configurations aren't read when the block isn't configured
+			configured:   []interface{}{},
+			expected:     []interface{}{},
+			expectedGets: 0,
+		},
+		{
+			// only the configured values are read, even when these match the default
+			configured: []interface{}{
+				map[string]interface{}{
+					"max_connections":     300,
+					"wait_timeout":        0,
+					"interactive_timeout": 0,
+				},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"max_connections":     300,
+					"wait_timeout":        0,
+					"interactive_timeout": 0,
+				},
+			},
+			expectedGets: 1,
+		},
+		{
+			// configured values which have been changed outside of Terraform are returned so they're reset
+			configured: []interface{}{
+				map[string]interface{}{
+					"max_connections":     0,
+					"wait_timeout":        120,
+					"interactive_timeout": 0,
+				},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"max_connections":     0,
+					"wait_timeout":        600,
+					"interactive_timeout": 0,
+				},
+			},
+			expectedGets: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		gets := 0
+		client := mysql.NewConfigurationsClient("00000000-0000-0000-0000-000000000000")
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			gets++
+			segments := strings.Split(r.URL.Path, "/")
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(responses[segments[len(segments)-1]])),
+				Request:    r,
+			}, nil
+		})
+
+		actual, err := flattenMySQLServerConnectionLimits(context.Background(), client, "group1", "server1", test.configured)
+		if err != nil {
+			t.Fatalf("Expected no error flattening the connection limits but got: %+v", err)
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Fatalf("Expected %+v but got %+v", test.expected, actual)
+		}
+
+		if gets != test.expectedGets {
+			t.Fatalf("Expected %d GET requests but got %d", test.expectedGets, gets)
+		}
+	}
+}
+
+func TestSetMySQLServerAuditLog(t *testing.T) {
+	testCases := []struct {
+		input          []interface{}
+		expectedValues []string
+		expectedGets   int
+	}{
+		{
+			// the events are set (in a consistent order) before the Audit Log is enabled
+			input: []interface{}{
+				map[string]interface{}{
+					"enabled": true,
+					"events":  schema.NewSet(schema.HashString, []interface{}{"DDL", "CONNECTION", "DCL"}),
+				},
+			},
+			expectedValues: []string{"CONNECTION,DCL,DDL", "ON"},
+			expectedGets:   0,
+		},
+		{
+			// events which aren't specified are reset to the default
+			input: []interface{}{
+				map[string]interface{}{
+					"enabled": false,
+					"events":  schema.NewSet(schema.HashString, []interface{}{}),
+				},
+			},
+			expectedValues: []string{"DEFAULT", "OFF"},
+			expectedGets:   1,
+		},
+		{
+			// removing the block resets the configurations to their defaults
+			input:          []interface{}{},
+			expectedValues: []string{"DEFAULT", "DEFAULT"},
+			expectedGets:   2,
+		},
+	}
+
+	for _, test := range testCases {
+		gets := 0
+		values := make([]string, 0)
+		configurations := make([]string, 0)
+
+		client := mysql.NewConfigurationsClient("00000000-0000-0000-0000-000000000000")
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			body := `{"properties": {"value": "ON", "defaultValue": "DEFAULT"}}`
+
+			if r.Method == http.MethodGet {
+				gets++
+			} else {
+				var configuration mysql.Configuration
+				if err := json.NewDecoder(r.Body).Decode(&configuration); err != nil {
+					t.Fatalf("Error decoding request: %+v", err)
+				}
+
+				segments := strings.Split(r.URL.Path, "/")
+				configurations = append(configurations, segments[len(segments)-1])
+				values = append(values, *configuration.Value)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    r,
+			}, nil
+		})
+
+		err := setMySQLServerAuditLog(context.Background(), client, "group1", "server1", test.input)
+		if err != nil {
+			t.Fatalf("Expected no error setting the audit log but got: %+v", err)
+		}
+
+		expectedConfigurations := []string{"audit_log_events", "audit_log_enabled"}
+		if !reflect.DeepEqual(configurations, expectedConfigurations) {
+			t.Fatalf("Expected the configurations %v to be set but got %v", expectedConfigurations, configurations)
+		}
+
+		if !reflect.DeepEqual(values, test.expectedValues) {
+			t.Fatalf("Expected the values %v but got %v", test.expectedValues, values)
+		}
+
+		if gets != test.expectedGets {
+			t.Fatalf("Expected %d GET requests but got %d", test.expectedGets, gets)
+		}
+	}
+}
+
+func TestFlattenMySQLServerAuditLog(t *testing.T) {
+	defaults := map[string]string{
+		"audit_log_enabled": `{"properties": {"value": "OFF", "defaultValue": "OFF"}}`,
+		"audit_log_events":  `{"properties": {"value": "CONNECTION", "defaultValue": "CONNECTION"}}`,
+	}
+
+	testCases := []struct {
+		name            string
+		responses       map[string]string
+		configured      []interface{}
+		expectedBlock   bool
+		expectedEnabled bool
+		expectedEvents  []string
+		expectedGets    int
+	}{
+		{
+			name:          "defaults",
+			responses:     defaults,
+			configured:    []interface{}{},
+			expectedBlock: false,
+			expectedGets:  0,
+		},
+		{
+			name:          "disabled",
+			responses:     defaults,
+			configured:    []interface{}{map[string]interface{}{"enabled": false}},
+			expectedBlock: true,
+			expectedGets:  1,
+		},
+		{
+			name: "enabled with the default events",
+			responses: map[string]string{
+				"audit_log_enabled": `{"properties": {"value": "ON", "defaultValue": "OFF"}}`,
+				"audit_log_events":  `{"properties": {"value": "CONNECTION", "defaultValue": "CONNECTION"}}`,
+			},
+			configured:      []interface{}{map[string]interface{}{"enabled": true}},
+			expectedBlock:   true,
+			expectedEnabled: true,
+			expectedGets:    1,
+		},
+		{
+			name: "configured events",
+			responses: map[string]string{
+				"audit_log_enabled": `{"properties": {"value": "ON", "defaultValue": "OFF"}}`,
+				"audit_log_events":  `{"properties": {"value": "CONNECTION", "defaultValue": "CONNECTION"}}`,
+			},
+			configured: []interface{}{
+				map[string]interface{}{
+					"enabled": true,
+					"events":  schema.NewSet(schema.HashString, []interface{}{"CONNECTION"}),
+				},
+			},
+			expectedBlock:   true,
+			expectedEnabled: true,
+			expectedEvents:  []string{"CONNECTION"},
+			expectedGets:    2,
+		},
+		{
+			// the Server Configurations aren't read when the block isn't configured, since they may be managed elsewhere
+			name: "changed outside of Terraform",
+			responses: map[string]string{
+				"audit_log_enabled": `{"properties": {"value": "on", "defaultValue": "OFF"}}`,
+				"audit_log_events":  `{"properties": {"value": "DDL, dcl", "defaultValue": "CONNECTION"}}`,
+			},
+			configured:    []interface{}{},
+			expectedBlock: false,
+			expectedGets:  0,
+		},
+		{
+			// configured events which have been changed outside of Terraform are returned so they're reset
+			name: "configured events changed outside of Terraform",
+			responses: map[string]string{
+				"audit_log_enabled": `{"properties": {"value": "on", "defaultValue": "OFF"}}`,
+				"audit_log_events":  `{"properties": {"value": "DDL, dcl", "defaultValue": "CONNECTION"}}`,
+			},
+			configured: []interface{}{
+				map[string]interface{}{
+					"enabled": true,
+					"events":  schema.NewSet(schema.HashString, []interface{}{"DDL"}),
+				},
+			},
+			expectedBlock:   true,
+			expectedEnabled: true,
+			expectedEvents:  []string{"DCL", "DDL"},
+			expectedGets:    2,
+		},
+	}
+
+	for _, test := range testCases {
+		gets := 0
+		client := mysql.NewConfigurationsClient("00000000-0000-0000-0000-000000000000")
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			gets++
+			segments := strings.Split(r.URL.Path, "/")
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(test.responses[segments[len(segments)-1]])),
+				Request:    r,
+			}, nil
+		})
+
+		actual, err := flattenMySQLServerAuditLog(context.Background(), client, "group1", "server1", test.configured)
+		if err != nil {
+			t.Fatalf("Expected no error flattening the audit log for %q but got: %+v", test.name, err)
+		}
+
+		if gets != test.expectedGets {
+			t.Fatalf("Expected %d GET requests for %q but got %d", test.expectedGets, test.name, gets)
+		}
+
+		if !test.expectedBlock {
+			if len(actual) != 0 {
+				t.Fatalf("Expected no `audit_log` block for %q but got %+v", test.name, actual)
+			}
+			continue
+		}
+
+		if len(actual) != 1 {
+			t.Fatalf("Expected an `audit_log` block for %q but got %+v", test.name, actual)
+		}
+
+		auditLog := actual[0].(map[string]interface{})
+		if enabled := auditLog["enabled"].(bool); enabled != test.expectedEnabled {
+			t.Fatalf("Expected `enabled` to be %t for %q but got %t", test.expectedEnabled, test.name, enabled)
+		}
+
+		events := make([]string, 0)
+		for _, v := range auditLog["events"].(*schema.Set).List() {
+			events = append(events, v.(string))
+		}
+		sort.Strings(events)
+
+		if len(events) != len(test.expectedEvents) || (len(events) > 0 && !reflect.DeepEqual(events, test.expectedEvents)) {
+			t.Fatalf("Expected the events %v for %q but got %v", test.expectedEvents, test.name, events)
+		}
+	}
+}
+
+func TestMySQLServerWriteOnlyPasswordDiff(t *testing.T) {
+	testCases := []struct {
+		name          string
+		statePassword string
+		stateVersion  string
+		password      string
+		version       int
+		expectDiff    bool
+	}{
+		{
+			name:          "write-only and unchanged",
+			statePassword: "",
+			stateVersion:  "1",
+			password:      "H@Sh1CoR3!",
+			version:       1,
+			expectDiff:    false,
+		},
+		{
+			name:          "write-only and rotated",
+			statePassword: "",
+			stateVersion:  "1",
+			password:      "H@Sh1CoR3!2",
+			version:       2,
+			expectDiff:    true,
+		},
+		{
+			name:          "not write-only",
+			statePassword: "H@Sh1CoR3!",
+			stateVersion:  "",
+			password:      "H@Sh1CoR3!2",
+			version:       0,
+			expectDiff:    true,
+		},
+	}
+
+	r := resourceArmMySqlServer()
+
+	for _, test := range testCases {
+		state := &terraform.InstanceState{
+			ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1",
+			Attributes: map[string]string{
+				"name":                         "server1",
+				"location":                     "westeurope",
+				"resource_group_name":          "group1",
+				"create_mode":                  "Default",
+				"administrator_login":          "acctestun",
+				"administrator_login_password": test.statePassword,
+				"version":                      "5.7",
+				"storage_mb":                   "51200",
+				"ssl_enforcement":              "Enabled",
+			},
+		}
+		if test.stateVersion != "" {
+			state.Attributes["password_wo_version"] = test.stateVersion
+		}
+
+		raw := map[string]interface{}{
+			"name":                         "server1",
+			"location":                     "westeurope",
+			"resource_group_name":          "group1",
+			"administrator_login":          "acctestun",
+			"administrator_login_password": test.password,
+			"version":                      "5.7",
+			"storage_mb":                   51200,
+			"ssl_enforcement":              "Enabled",
+			"sku": []interface{}{
+				map[string]interface{}{
+					"name":     "MYSQLB50",
+					"capacity": 50,
+					"tier":     "Basic",
+				},
+			},
+		}
+		if test.version != 0 {
+			raw["password_wo_version"] = test.version
+		}
+
+		diff, err := r.Diff(state, terraform.NewResourceConfig(config.TestRawConfig(t, raw)), &ArmClient{})
+		if err != nil {
+			t.Fatalf("Expected no error for %q but got: %+v", test.name, err)
+		}
+
+		if diff.RequiresNew() {
+			t.Fatalf("Expected %q to be updated in-place but got: %+v", test.name, diff)
+		}
+
+		_, hasDiff := diff.Attributes["administrator_login_password"]
+		if hasDiff != test.expectDiff {
+			t.Fatalf("Expected a diff for the `administrator_login_password` %t for %q but got %t", test.expectDiff, test.name, hasDiff)
+		}
+	}
+}
+
+func TestClearMySQLServerWriteOnlyPassword(t *testing.T) {
+	testCases := []struct {
+		version  int
+		expected string
+	}{
+		{
+			version:  1,
+			expected: "",
+		},
+		{
+			version:  0,
+			expected: "H@Sh1CoR3!",
+		},
+	}
+
+	for _, test := range testCases {
+		raw := map[string]interface{}{
+			"administrator_login_password": "H@Sh1CoR3!",
+		}
+		if test.version != 0 {
+			raw["password_wo_version"] = test.version
+		}
+
+		d := schema.TestResourceDataRaw(t, resourceArmMySqlServer().Schema, raw)
+		d.SetId("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1")
+
+		clearMySQLServerWriteOnlyPassword(d)
+
+		if actual := d.State().Attributes["administrator_login_password"]; actual != test.expected {
+			t.Fatalf("Expected the `administrator_login_password` in the state to be %q when `password_wo_version` is %d but got %q", test.expected, test.version, actual)
+		}
+	}
+}
+
+func testMySQLServerRequestError(code, message string) error {
+	return autorest.DetailedError{
+		Original: &azure.RequestError{
+			ServiceError: &azure.ServiceError{
+				Code:    code,
+				Message: message,
+			},
+		},
+	}
+}
+
+func TestMySQLServerPendingDeletion(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "plain error",
+			err:      errors.New("boom"),
+			expected: false,
+		},
+		{
+			name:     "unrelated conflict",
+			err:      testMySQLServerRequestError("Conflict", "Another operation is in progress on the server."),
+			expected: false,
+		},
+		{
+			name:     "pending deletion error code",
+			err:      testMySQLServerRequestError("ServerPendingDeletion", "The server can't be created."),
+			expected: true,
+		},
+		{
+			name:     "conflict mentioning pending deletion",
+			err:      testMySQLServerRequestError("Conflict", "The server name 'server1' is pending deletion."),
+			expected: false,
+		},
+	}
+
+	for _, test := range testCases {
+		if actual := mysqlServerPendingDeletion(test.err); actual != test.expected {
+			t.Fatalf("Expected %q to be pending deletion %t but got %t", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestMySQLServerPendingDeletionFromResponse(t *testing.T) {
+	body, err := ioutil.ReadFile(filepath.Join("testdata", "mysql_server_pending_deletion.json"))
+	if err != nil {
+		t.Fatalf("Error loading the response body: %+v", err)
+	}
+
+	properties := mysql.ServerForCreate{
+		Location: utils.String("westeurope"),
+		Properties: &mysql.ServerPropertiesForDefaultCreate{
+			AdministratorLogin:         utils.String("acctestun"),
+			AdministratorLoginPassword: utils.String("H@Sh1CoR3!"),
+		},
+	}
+
+	// when the Resource Provider registration isn't skipped the SDK returns the Conflict as a plain error
+	for _, skip := range []bool{true, false} {
+		client := mysql.NewServersClient("00000000-0000-0000-0000-000000000000")
+		client.SkipResourceProviderRegistration = skip
+		client.Sender = autorest.DecorateSender(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusConflict,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(bytes.NewReader(body)),
+				Request:    r,
+			}, nil
+		}), withBufferedErrorResponses())
+
+		_, err = client.CreateOrUpdate(context.Background(), "group1", "server1", properties)
+		if err == nil {
+			t.Fatalf("Expected an error creating the MySQL Server (skipping registration %t) but didn't get one", skip)
+		}
+
+		if !mysqlServerPendingDeletion(err) {
+			t.Fatalf("Expected the error to be detected as pending deletion (skipping registration %t) but it wasn't: %+v", skip, err)
+		}
+	}
+}
+
+func TestRetryMySQLServerCreationWhilstPendingDeletion(t *testing.T) {
+	pendingDeletion := testMySQLServerRequestError("ServerPendingDeletion", "The server is pending deletion.")
+
+	// the creation succeeds once the deleted server has been purged
+	attempts := 0
+	err := retryMySQLServerCreationWhilstPendingDeletion(context.Background(), "server1", "group1", time.Second, time.Millisecond, func() error {
+		attempts++
+		if attempts < 3 {
+			return pendingDeletion
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error once the deleted server was purged but got: %+v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("Expected 3 attempts but got %d", attempts)
+	}
+
+	// other errors aren't retried
+	attempts = 0
+	err = retryMySQLServerCreationWhilstPendingDeletion(context.Background(), "server1", "group1", time.Second, time.Millisecond, func() error {
+		attempts++
+		return errors.New("boom")
+	})
+	if err == nil || err.Error() != "boom" {
+		t.Fatalf("Expected the original error but got: %+v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("Expected 1 attempt but got %d", attempts)
+	}
+
+	// once the timeout's reached the error explains how to resolve this
+	err = retryMySQLServerCreationWhilstPendingDeletion(context.Background(), "server1", "group1", 5*time.Millisecond, time.Millisecond, func() error {
+		return pendingDeletion
+	})
+	if err == nil || !strings.Contains(err.Error(), "pending deletion and wasn't purged within 5ms") {
+		t.Fatalf("Expected an error explaining the server is pending deletion but got: %+v", err)
+	}
+}
+
+func TestMySQLServerBackupRetentionDays(t *testing.T) {
+	tiers := []mysql.PerformanceTierProperties{
+		{ID: utils.String("Basic"), BackupRetentionDays: utils.Int32(7)},
+		{ID: utils.String("Standard"), BackupRetentionDays: utils.Int32(35)},
+		{ID: utils.String("Premium")},
+	}
+
+	testCases := []struct {
+		tier        string
+		expected    int
+		shouldError bool
+	}{
+		{"Basic", 7, false},
+		{"standard", 35, false},
+		{"Premium", 0, true},
+		{"GeneralPurpose", 0, true},
+	}
+
+	for _, test := range testCases {
+		actual, err := mysqlServerBackupRetentionDays(&tiers, test.tier)
+		if test.shouldError {
+			if err == nil {
+				t.Fatalf("Expected an error for the tier %q but didn't get one", test.tier)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Expected no error for the tier %q but got: %+v", test.tier, err)
+		}
+
+		if actual != test.expected {
+			t.Fatalf("Expected %d days for the tier %q but got %d", test.expected, test.tier, actual)
+		}
+	}
+
+	if _, err := mysqlServerBackupRetentionDays(nil, "Basic"); err == nil {
+		t.Fatalf("Expected an error when there are no Performance Tiers")
+	}
+}
+
+func TestValidateMySQLServerRestorePointInTime(t *testing.T) {
+	now := time.Date(2018, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		restorePointInTime time.Time
+		shouldError        bool
+	}{
+		{now, false},
+		{now.Add(-1 * time.Hour), false},
+		{now.Add(-7 * 24 * time.Hour), false},
+		{now.Add(-7*24*time.Hour - time.Minute), true},
+		{now.Add(time.Minute), true},
+	}
+
+	for _, test := range testCases {
+		err := validateMySQLServerRestorePointInTime(test.restorePointInTime, now, 7)
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected an error restoring to %s but didn't get one", test.restorePointInTime)
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected no error restoring to %s but got: %+v", test.restorePointInTime, err)
+		}
+	}
+}
+
+func TestGetMySQLServerAfterCreate(t *testing.T) {
+	testCases := []struct {
+		statusCodes      []int
+		shouldError      bool
+		expectedRequests int
+	}{
+		{[]int{http.StatusOK}, false, 1},
+		{[]int{http.StatusNotFound, http.StatusNotFound, http.StatusOK}, false, 3},
+		{[]int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK}, false, 3},
+		{[]int{http.StatusBadRequest}, true, 1},
+		{[]int{http.StatusForbidden}, true, 1},
+	}
+
+	for _, test := range testCases {
+		requests := 0
+		client := mysql.NewServersClient("00000000-0000-0000-0000-000000000000")
+		// the SDK retries a request which failed temporarily once, after which it's retried by getMySQLServerAfterCreate
+		client.RetryAttempts = 1
+		client.RetryDuration = 0
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			statusCode := test.statusCodes[requests]
+			requests++
+
+			return &http.Response{
+				StatusCode: statusCode,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1"}`)),
+				Request:    r,
+			}, nil
+		})
+
+		_, err := getMySQLServerAfterCreate(context.Background(), client, "group1", "server1", time.Minute)
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected an error for the status codes %v but didn't get one", test.statusCodes)
+		}
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected no error for the status codes %v but got: %+v", test.statusCodes, err)
+		}
+
+		if requests != test.expectedRequests {
+			t.Fatalf("Expected %d requests for the status codes %v but got %d", test.expectedRequests, test.statusCodes, requests)
+		}
+	}
+}
+
+func TestCreateMySQLServer_releasesLimiter(t *testing.T) {
+	limiter := newOperationLimiter(1)
+	heldDuringCreate := false
+
+	client := mysql.NewServersClient("00000000-0000-0000-0000-000000000000")
+	client.PollingDelay = 0
+	client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodPut {
+			heldDuringCreate = len(limiter.slots) == 1
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.DBforMySQL/servers/server1", "properties": {"userVisibleState": "Ready"}}`)),
+			Request:    r,
+		}, nil
+	})
+
+	properties := mysql.ServerForCreate{
+		Location: utils.String("westeurope"),
+		Properties: &mysql.ServerPropertiesForDefaultCreate{
+			AdministratorLogin:         utils.String("acctestun"),
+			AdministratorLoginPassword: utils.String("H@Sh1CoR3!"),
+		},
+	}
+
+	if _, err := createMySQLServer(context.Background(), client, limiter, "group1", "server1", properties); err != nil {
+		t.Fatalf("Expected no error creating the MySQL Server but got: %+v", err)
+	}
+
+	if !heldDuringCreate {
+		t.Fatalf("Expected the limiter to be held whilst the MySQL Server was created")
+	}
+
+	// the slot should be released once the MySQL Server's ready, rather than once the configuration's been updated
+	if len(limiter.slots) != 0 {
+		t.Fatalf("Expected the limiter to be released once the MySQL Server was created")
+	}
+}
+
 func TestAccAzureRMMySQLServer_basicFiveSix(t *testing.T) {
 	resourceName := "azurerm_mysql_server.test"
 	ri := acctest.RandInt()
@@ -24,6 +1280,7 @@ func TestAccAzureRMMySQLServer_basicFiveSix(t *testing.T) {
 				Config: config,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMMySQLServerExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "connection_string"),
 				),
 			},
 		},
@@ -70,6 +1327,177 @@ func TestAccAzureRMMySqlServer_standard(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMMySQLServer_queryStore(t *testing.T) {
+	resourceName := "azurerm_mysql_server.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMySQLServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMMySQLServer_queryStore(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMySQLServerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "query_store.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "query_store.0.capture_mode", "ALL"),
+					resource.TestCheckResourceAttr(resourceName, "query_store.0.wait_sampling_capture_mode", "NONE"),
+				),
+			},
+			{
+				Config: testAccAzureRMMySQLServer_standard(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMySQLServerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "query_store.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMMySQLServer_writeOnlyPassword(t *testing.T) {
+	resourceName := "azurerm_mysql_server.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMySQLServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMMySQLServer_writeOnlyPassword(ri, location, "H@Sh1CoR3!", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMySQLServerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "administrator_login_password", ""),
+					resource.TestCheckResourceAttr(resourceName, "password_wo_version", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMMySQLServer_writeOnlyPassword(ri, location, "R0tat3d!P@ssw0rd", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMySQLServerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "administrator_login_password", ""),
+					resource.TestCheckResourceAttr(resourceName, "password_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMMySQLServer_connectionLimits(t *testing.T) {
+	resourceName := "azurerm_mysql_server.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMySQLServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMMySQLServer_connectionLimits(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMySQLServerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "connection_limits.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "connection_limits.0.max_connections", "500"),
+					resource.TestCheckResourceAttr(resourceName, "connection_limits.0.wait_timeout", "600"),
+					resource.TestCheckResourceAttr(resourceName, "connection_limits.0.interactive_timeout", "0"),
+				),
+			},
+			{
+				Config: testAccAzureRMMySQLServer_standard(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMySQLServerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "connection_limits.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMMySQLServer_auditLog(t *testing.T) {
+	resourceName := "azurerm_mysql_server.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMySQLServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMMySQLServer_auditLog(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMySQLServerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "audit_log.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "audit_log.0.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "audit_log.0.events.#", "2"),
+				),
+			},
+			{
+				Config: testAccAzureRMMySQLServer_standard(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMySQLServerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "audit_log.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMMySQLServer_restorePointInTime(t *testing.T) {
+	resourceName := "azurerm_mysql_server.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+	preConfig := testAccAzureRMMySQLServer_basicFiveSeven(ri, location)
+	timeToRestore := time.Now().Add(15 * time.Minute)
+	formattedTime := timeToRestore.UTC().Format(time.RFC3339)
+	postConfig := testAccAzureRMMySQLServer_restorePointInTime(ri, location, formattedTime)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMySQLServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: preConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMySQLServerExists(resourceName),
+				),
+			},
+			{
+				PreConfig: func() { time.Sleep(timeToRestore.Sub(time.Now().Add(-1 * time.Minute))) },
+				Config:    postConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMySQLServerExists(resourceName),
+					testCheckAzureRMMySQLServerExists("azurerm_mysql_server.restore"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMMySQLServer_restorePointInTimeOutsideRetention(t *testing.T) {
+	ri := acctest.RandInt()
+	location := testLocation()
+	formattedTime := time.Now().Add(-30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMySQLServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAzureRMMySQLServer_restorePointInTime(ri, location, formattedTime),
+				ExpectError: regexp.MustCompile("backup retention period"),
+			},
+		},
+	})
+}
+
 func testCheckAzureRMMySQLServerExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// Ensure we have enough information in state to look up in API
@@ -205,3 +1633,155 @@ resource "azurerm_mysql_server" "test" {
 }
 `, rInt, location, rInt)
 }
+
+func testAccAzureRMMySQLServer_queryStore(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_mysql_server" "test" {
+  name                = "acctestmysqlsvr-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  sku {
+    name     = "MYSQLS200"
+    capacity = 200
+    tier     = "Standard"
+  }
+
+  administrator_login          = "acctestun"
+  administrator_login_password = "H@Sh1CoR3!"
+  version                      = "5.7"
+  storage_mb                   = 640000
+  ssl_enforcement              = "Enabled"
+
+  query_store {
+    capture_mode               = "ALL"
+    wait_sampling_capture_mode = "NONE"
+  }
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMMySQLServer_connectionLimits(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_mysql_server" "test" {
+  name                = "acctestmysqlsvr-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  sku {
+    name     = "MYSQLS200"
+    capacity = 200
+    tier     = "Standard"
+  }
+
+  administrator_login          = "acctestun"
+  administrator_login_password = "H@Sh1CoR3!"
+  version                      = "5.7"
+  storage_mb                   = 640000
+  ssl_enforcement              = "Enabled"
+
+  connection_limits {
+    max_connections = 500
+    wait_timeout    = 600
+  }
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMMySQLServer_auditLog(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_mysql_server" "test" {
+  name                = "acctestmysqlsvr-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  sku {
+    name     = "MYSQLS200"
+    capacity = 200
+    tier     = "Standard"
+  }
+
+  administrator_login          = "acctestun"
+  administrator_login_password = "H@Sh1CoR3!"
+  version                      = "5.7"
+  storage_mb                   = 640000
+  ssl_enforcement              = "Enabled"
+
+  audit_log {
+    events = ["CONNECTION", "DDL"]
+  }
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMMySQLServer_writeOnlyPassword(rInt int, location string, password string, passwordVersion int) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_mysql_server" "test" {
+  name                = "acctestmysqlsvr-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  sku {
+    name     = "MYSQLS200"
+    capacity = 200
+    tier     = "Standard"
+  }
+
+  administrator_login          = "acctestun"
+  administrator_login_password = "%s"
+  password_wo_version          = %d
+  version                      = "5.7"
+  storage_mb                   = 640000
+  ssl_enforcement              = "Enabled"
+}
+`, rInt, location, rInt, password, passwordVersion)
+}
+
+func testAccAzureRMMySQLServer_restorePointInTime(rInt int, location string, restorePointInTime string) string {
+	template := testAccAzureRMMySQLServer_basicFiveSeven(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_mysql_server" "restore" {
+  name                = "acctestmysqlsvr-%d-restore"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  sku {
+    name     = "MYSQLB50"
+    capacity = 50
+    tier     = "Basic"
+  }
+
+  administrator_login          = "acctestun"
+  administrator_login_password = "H@Sh1CoR3!"
+  version                      = "5.7"
+  storage_mb                   = 51200
+  ssl_enforcement              = "Enabled"
+
+  create_mode           = "PointInTimeRestore"
+  source_server_id      = "${azurerm_mysql_server.test.id}"
+  restore_point_in_time = "%s"
+}
+`, template, rInt, restorePointInTime)
+}
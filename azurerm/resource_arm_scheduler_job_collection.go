@@ -1,13 +1,23 @@
 package azurerm
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"net/http"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2017-05-10/resources"
 	"github.com/Azure/azure-sdk-for-go/services/scheduler/mgmt/2016-03-01/scheduler"
-
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/structure"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -20,19 +30,23 @@ func resourceArmSchedulerJobCollection() *schema.Resource {
 		Update: resourceArmSchedulerJobCollectionCreateUpdate,
 		Delete: resourceArmSchedulerJobCollectionDelete,
 
+		CustomizeDiff: resourceArmSchedulerJobCollectionCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceArmSchedulerJobCollectionImport,
 		},
+		MigrateState:  resourceAzureRMSchedulerJobCollectionMigrateState,
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
-				ValidateFunc: validation.StringMatch(
+				ValidateFunc: validateSchedulerRetirement(validation.StringMatch(
 					regexp.MustCompile("^[a-zA-Z][-_a-zA-Z0-9]{0,99}$"),
 					"Job Collection Name name must be 1 - 100 characters long, start with a letter and contain only letters, numbers, hyphens and underscores.",
-				),
+				)),
 			},
 
 			"location": locationSchema(),
@@ -45,31 +59,33 @@ func resourceArmSchedulerJobCollection() *schema.Resource {
 				Type:             schema.TypeString,
 				Required:         true,
 				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
-				ValidateFunc: validation.StringInSlice([]string{
+				ValidateFunc: validateSchedulerDeprecatedSku(validation.StringInSlice([]string{
 					string(scheduler.Free),
 					string(scheduler.Standard),
 					string(scheduler.P10Premium),
 					string(scheduler.P20Premium),
-				}, true),
+				}, true)),
 			},
 
 			//optional
-			"state": {
-				Type:             schema.TypeString,
-				Optional:         true,
-				Default:          string(scheduler.Enabled),
-				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
-				ValidateFunc: validation.StringInSlice([]string{
-					string(scheduler.Enabled),
-					string(scheduler.Suspended),
-					string(scheduler.Disabled),
-				}, true),
+			"state": stateEnumSchema(string(scheduler.Enabled), []string{
+				string(scheduler.Enabled),
+				string(scheduler.Suspended),
+				string(scheduler.Disabled),
+			}),
+
+			"ignore_external_state_changes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 
 			"quota": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Deprecated:    "`quota` has been replaced by the top-level `max_job_count`, `max_recurrence_frequency` and `max_retry_interval` fields and will be removed in a future version",
+				ConflictsWith: []string{"max_job_count", "max_recurrence_frequency", "max_retry_interval"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 
@@ -81,9 +97,10 @@ func resourceArmSchedulerJobCollection() *schema.Resource {
 							ValidateFunc: validation.IntAtLeast(0),
 						},
 
+						//this is required when the block contains anything else, which is checked in CustomizeDiff
 						"max_recurrence_frequency": {
 							Type:             schema.TypeString,
-							Required:         true,
+							Optional:         true,
 							DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
 							ValidateFunc: validation.StringInSlice([]string{
 								string(scheduler.Minute),
@@ -99,163 +116,1429 @@ func resourceArmSchedulerJobCollection() *schema.Resource {
 						"max_retry_interval": {
 							Type:         schema.TypeInt,
 							Optional:     true,
-							ValidateFunc: validation.IntAtLeast(1), //changes depending on the frequency, unknown maximums
+							ValidateFunc: validation.IntAtLeast(1), //the maximum depends on the frequency and is checked in CustomizeDiff
+						},
+					},
+				},
+			},
+
+			//the quota is also exposed as top-level fields, so changes to individual values are clear in the plan
+			"max_job_count": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.IntAtLeast(0),
+				ConflictsWith: []string{"quota"},
+			},
+
+			"max_recurrence_frequency": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(scheduler.Minute),
+					string(scheduler.Hour),
+					string(scheduler.Day),
+					string(scheduler.Week),
+					string(scheduler.Month),
+				}, true),
+				ConflictsWith: []string{"quota"},
+			},
+
+			"max_retry_interval": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.IntAtLeast(1), //the maximum depends on the frequency and is checked in CustomizeDiff
+				ConflictsWith: []string{"quota"},
+			},
+
+			//the quota actually applied by Azure, which may differ from the `quota` requested (e.g. for the Free SKU)
+			"effective_quota": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_job_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"max_recurrence_frequency": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"max_retry_interval": {
+							Type:     schema.TypeInt,
+							Computed: true,
 						},
 					},
 				},
 			},
+
+			"force_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"error_on_free_sku_quota": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			//counting the jobs requires listing them, so this is opt-in
+			"include_job_count": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"suspend_jobs": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			//when creating only check the Job Collection could be created (e.g. in CI) during the plan, without creating it
+			"validate_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			//the Jobs disabled by `suspend_jobs`, so only these are re-enabled - and not any Jobs which were already disabled
+			"suspended_jobs": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"job_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			//properties which aren't modelled yet, merged into those sent to the API - explicit fields take precedence
+			"additional_properties_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateSchedulerJobCollectionAdditionalPropertiesJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+
+			//the raw properties returned from the API, so that properties which aren't modelled yet can be read
+			"properties_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func resourceArmSchedulerJobCollectionCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).schedulerJobCollectionsClient
-	ctx := meta.(*ArmClient).StopContext
+// schedulerJobCollectionDeleteTimeout is how long we'll keep polling a deletion which is failing temporarily (e.g. since it's throttled)
+const schedulerJobCollectionDeleteTimeout = 30 * time.Minute
 
-	name := d.Get("name").(string)
-	location := d.Get("location").(string)
-	resourceGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
+// schedulerJobCollectionProvisioningTimeout is how long to wait for a newly created Job Collection to be usable
+const schedulerJobCollectionProvisioningTimeout = 10 * time.Minute
 
-	log.Printf("[DEBUG] Creating/updating Scheduler Job Collection %q (resource group %q)", name, resourceGroup)
+// schedulerJobCollectionTagsUpdateAttempts is how many times to try patching the tags when they're being modified concurrently
+const schedulerJobCollectionTagsUpdateAttempts = 3
 
-	collection := scheduler.JobCollectionDefinition{
-		Location: utils.String(location),
-		Tags:     expandTags(tags),
-		Properties: &scheduler.JobCollectionProperties{
-			Sku: &scheduler.Sku{
-				Name: scheduler.SkuDefinition(d.Get("sku").(string)),
-			},
-		},
+// the maximum recurrence interval for each frequency, these all work out to roughly 500 days
+var schedulerJobCollectionMaxRecurrenceIntervals = map[string]int{
+	strings.ToLower(string(scheduler.Minute)): 72000,
+	strings.ToLower(string(scheduler.Hour)):   12000,
+	strings.ToLower(string(scheduler.Day)):    500,
+	strings.ToLower(string(scheduler.Week)):   71,
+	strings.ToLower(string(scheduler.Month)):  16,
+}
+
+// the SKU transitions which can be made in-place - any other change to the SKU (e.g. downgrading from a Premium SKU
+// to `Standard` or `Free`) requires the Job Collection to be recreated
+var schedulerJobCollectionInPlaceSkuTransitions = map[string][]string{
+	strings.ToLower(string(scheduler.Free)): {
+		strings.ToLower(string(scheduler.Standard)),
+		strings.ToLower(string(scheduler.P10Premium)),
+		strings.ToLower(string(scheduler.P20Premium)),
+	},
+	strings.ToLower(string(scheduler.Standard)): {
+		strings.ToLower(string(scheduler.P10Premium)),
+		strings.ToLower(string(scheduler.P20Premium)),
+	},
+	// moving between the Premium tiers only changes the limits
+	strings.ToLower(string(scheduler.P10Premium)): {
+		strings.ToLower(string(scheduler.P20Premium)),
+	},
+	strings.ToLower(string(scheduler.P20Premium)): {
+		strings.ToLower(string(scheduler.P10Premium)),
+	},
+}
+
+func resourceArmSchedulerJobCollectionCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+	if diff.Id() != "" && diff.HasChange("sku") {
+		old, new := diff.GetChange("sku")
+		if !schedulerJobCollectionSkuTransitionSupported(old.(string), new.(string)) {
+			log.Printf("[DEBUG] Scheduler Job Collection SKU can't be changed from %q to %q in-place - recreating", old, new)
+			if err := diff.ForceNew("sku"); err != nil {
+				return err
+			}
+		}
 	}
 
-	if state, ok := d.Get("state").(string); ok {
-		collection.Properties.State = scheduler.JobCollectionState(state)
+	//validating the creation against the API during the plan, when the values it needs are known
+	if diff.Id() == "" && diff.Get("validate_only").(bool) {
+		name := diff.Get("name").(string)
+		resourceGroup := diff.Get("resource_group_name").(string)
+		location := diff.Get("location").(string)
+
+		if name != "" && resourceGroup != "" && location != "" {
+			client := v.(*ArmClient)
+			if err := validateSchedulerJobCollectionCreation(client.StopContext, client.schedulerJobCollectionsClient, client.providersClient, resourceGroup, name, location); err != nil {
+				return err
+			}
+		}
 	}
-	collection.Properties.Quota = expandAzureArmSchedulerJobCollectionQuota(d)
 
-	//create job collection
-	collection, err := client.CreateOrUpdate(ctx, resourceGroup, name, collection)
-	if err != nil {
-		return fmt.Errorf("Error creating/updating Scheduler Job Collection %q (Resource Group %q): %+v", name, resourceGroup, err)
+	//any Jobs which are still suspended (e.g. when re-enabling them previously failed) need re-enabling
+	if !diff.Get("suspend_jobs").(bool) && diff.Get("suspended_jobs").(*schema.Set).Len() > 0 {
+		if err := diff.SetNewComputed("suspended_jobs"); err != nil {
+			return err
+		}
 	}
 
-	//ensure collection actually exists and we have the correct ID
-	collection, err = client.Get(ctx, resourceGroup, name)
-	if err != nil {
-		return fmt.Errorf("Error reading Scheduler Job Collection %q after create/update (Resource Group %q): %+v", name, resourceGroup, err)
+	if quotaBlock, ok := schedulerJobCollectionQuotaBlock(diff.Get("quota").([]interface{})); ok {
+		if err := validateSchedulerJobCollectionQuotaBlock(quotaBlock); err != nil {
+			return err
+		}
 	}
 
-	d.SetId(*collection.ID)
+	quota, ok := schedulerJobCollectionQuotaFromDiff(diff)
+	if !ok {
+		return nil
+	}
 
-	return resourceArmSchedulerJobCollectionPopulate(d, resourceGroup, &collection)
-}
+	//the API requires the frequency for the retry interval, which the (deprecated) `quota` block required
+	if quota.specified && quota.maxRetryInterval != 0 && quota.maxRecurrenceFrequency == "" {
+		return fmt.Errorf("`max_recurrence_frequency` must be specified when `max_retry_interval` is specified")
+	}
 
-func resourceArmSchedulerJobCollectionRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).schedulerJobCollectionsClient
-	ctx := meta.(*ArmClient).StopContext
+	if err := validateSchedulerJobCollectionMaxRecurrence(quota.maxRecurrenceFrequency, quota.maxRetryInterval); err != nil {
+		return err
+	}
 
-	id, err := parseAzureResourceID(d.Id())
-	if err != nil {
+	sku := diff.Get("sku").(string)
+	if quota.specified {
+		if err := checkSchedulerJobCollectionFreeSkuQuota(sku, diff.Get("error_on_free_sku_quota").(bool)); err != nil {
+			return err
+		}
+	}
+
+	if err := validateSchedulerJobCollectionMaxJobCount(sku, quota.maxJobCount); err != nil {
 		return err
 	}
 
-	name := id.Path["jobCollections"]
-	resourceGroup := id.ResourceGroup
+	return nil
+}
 
-	log.Printf("[DEBUG] Reading Scheduler Job Collection %q (resource group %q)", name, resourceGroup)
+type schedulerJobCollectionQuotaValues struct {
+	maxJobCount            int
+	maxRecurrenceFrequency string
+	maxRetryInterval       int
 
-	collection, err := client.Get(ctx, resourceGroup, name)
-	if err != nil {
-		if utils.ResponseWasNotFound(collection.Response) {
-			d.SetId("")
-			return nil
-		}
+	// specified is whether the quota is being set by the user, rather than having been read from Azure
+	specified bool
+}
+
+// schedulerJobCollectionQuotaFromDiff returns the quota from either the (deprecated) `quota` block, or the top-level fields
+func schedulerJobCollectionQuotaFromDiff(diff *schema.ResourceDiff) (*schedulerJobCollectionQuotaValues, bool) {
+	if quotaBlock, ok := schedulerJobCollectionQuotaBlock(diff.Get("quota").([]interface{})); ok {
+		return &schedulerJobCollectionQuotaValues{
+			maxJobCount:            quotaBlock["max_job_count"].(int),
+			maxRecurrenceFrequency: quotaBlock["max_recurrence_frequency"].(string),
+			maxRetryInterval:       quotaBlock["max_retry_interval"].(int),
+			specified:              true,
+		}, true
+	}
 
-		return fmt.Errorf("Error making Read request on Scheduler Job Collection %q (Resource Group %q): %+v", name, resourceGroup, err)
+	quota := schedulerJobCollectionQuotaValues{
+		maxJobCount:            diff.Get("max_job_count").(int),
+		maxRecurrenceFrequency: diff.Get("max_recurrence_frequency").(string),
+		maxRetryInterval:       diff.Get("max_retry_interval").(int),
 	}
 
-	return resourceArmSchedulerJobCollectionPopulate(d, resourceGroup, &collection)
+	if quota.maxJobCount == 0 && quota.maxRecurrenceFrequency == "" && quota.maxRetryInterval == 0 {
+		return nil, false
+	}
+
+	//since these are computed they're populated from Azure, so are only being specified when they change
+	quota.specified = diff.HasChange("max_job_count") || diff.HasChange("max_recurrence_frequency") || diff.HasChange("max_retry_interval")
+
+	return &quota, true
 }
 
-func resourceArmSchedulerJobCollectionPopulate(d *schema.ResourceData, resourceGroup string, collection *scheduler.JobCollectionDefinition) error {
+// schedulerJobCollectionQuotaBlock returns the (deprecated) `quota` block when one's been specified - an empty block
+// is treated as no quota, rather than sending a malformed quota (without the required frequency) to the API
+func schedulerJobCollectionQuotaBlock(input []interface{}) (map[string]interface{}, bool) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, false
+	}
 
-	//standard properties
-	d.Set("name", collection.Name)
-	d.Set("location", azureRMNormalizeLocation(*collection.Location))
-	d.Set("resource_group_name", resourceGroup)
-	flattenAndSetTags(d, collection.Tags)
+	quotaBlock, ok := input[0].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
 
-	//resource specific
-	if properties := collection.Properties; properties != nil {
-		if sku := properties.Sku; sku != nil {
-			d.Set("sku", sku.Name)
+	for _, v := range quotaBlock {
+		switch value := v.(type) {
+		case int:
+			if value != 0 {
+				return quotaBlock, true
+			}
+		case string:
+			if value != "" {
+				return quotaBlock, true
+			}
 		}
-		d.Set("state", string(properties.State))
+	}
+
+	return nil, false
+}
+
+func validateSchedulerJobCollectionQuotaBlock(quotaBlock map[string]interface{}) error {
+	if v, ok := quotaBlock["max_recurrence_frequency"].(string); !ok || v == "" {
+		return fmt.Errorf("`max_recurrence_frequency` must be specified within the `quota` block - alternatively remove the `quota` block")
+	}
+
+	return nil
+}
+
+// checkSchedulerJobCollectionFreeSkuQuota flags a `quota` being specified for the Free SKU, which has fixed limits so the
+// quota is ignored/clamped by the service. By default a warning is logged, or an error is returned when `strict` is set.
+func checkSchedulerJobCollectionFreeSkuQuota(sku string, strict bool) error {
+	if !strings.EqualFold(sku, string(scheduler.Free)) {
+		return nil
+	}
+
+	message := "a `quota` has been specified for a Scheduler Job Collection using the `Free` SKU, which has fixed limits - as such the quota will be ignored or clamped by the service"
+	if strict {
+		return fmt.Errorf("%s. Either remove the `quota` block or use a paid `sku`", message)
+	}
+
+	log.Printf("[WARN] %s", message)
+	return nil
+}
+
+func validateSchedulerJobCollectionMaxJobCount(sku string, maxJobCount int) error {
+	// the SKU may not be known until apply
+	if sku == "" {
+		return nil
+	}
+
+	quota, ok := schedulerSkuQuotaForSku(sku)
+	if !ok {
+		return nil
+	}
+
+	if maxJobCount > quota.maxJobCount {
+		return fmt.Errorf("`max_job_count` must be at most %d when the `sku` is %q, got %d", quota.maxJobCount, sku, maxJobCount)
+	}
+
+	return nil
+}
 
-		if err := d.Set("quota", flattenAzureArmSchedulerJobCollectionQuota(properties.Quota)); err != nil {
-			return fmt.Errorf("Error flattening quota for Job Collection %q (Resource Group %q): %+v", collection.Name, resourceGroup, err)
+func schedulerJobCollectionSkuTransitionSupported(old, new string) bool {
+	// there's nothing to transition from when the SKU isn't known, e.g. when it couldn't be read
+	if old == "" || strings.EqualFold(old, new) {
+		return true
+	}
+
+	for _, sku := range schedulerJobCollectionInPlaceSkuTransitions[strings.ToLower(old)] {
+		if strings.EqualFold(sku, new) {
+			return true
 		}
 	}
 
+	return false
+}
+
+func validateSchedulerJobCollectionMaxRecurrence(frequency string, interval int) error {
+	// the interval may not be known until apply, or hasn't been specified
+	if frequency == "" || interval == 0 {
+		return nil
+	}
+
+	max, ok := schedulerJobCollectionMaxRecurrenceIntervals[strings.ToLower(frequency)]
+	if !ok {
+		return nil
+	}
+
+	if interval > max {
+		return fmt.Errorf("`max_retry_interval` must be at most %d when `max_recurrence_frequency` is %q, got %d", max, frequency, interval)
+	}
+
 	return nil
 }
 
-func resourceArmSchedulerJobCollectionDelete(d *schema.ResourceData, meta interface{}) error {
+func validateSchedulerJobCollectionAdditionalPropertiesJSON(v interface{}, k string) (ws []string, errors []error) {
+	var properties map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &properties); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a JSON object: %+v", k, err))
+	}
+
+	return ws, errors
+}
+
+func resourceArmSchedulerJobCollectionCreateUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).schedulerJobCollectionsClient
+	cache := meta.(*ArmClient).schedulerJobCollectionsCache
 	ctx := meta.(*ArmClient).StopContext
 
-	id, err := parseAzureResourceID(d.Id())
-	if err != nil {
-		return err
+	name := d.Get("name").(string)
+	location := d.Get("location").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	tags := d.Get("tags").(map[string]interface{})
+	additionalProperties := d.Get("additional_properties_json").(string)
+
+	log.Printf("[DEBUG] Creating/updating Scheduler Job Collection %q (resource group %q)", name, resourceGroup)
+
+	//when updating only apply our changes if the collection hasn't been modified since we last read it
+	etag := ""
+	if d.Id() != "" {
+		etag = d.Get("etag").(string)
 	}
 
-	name := id.Path["jobCollections"]
-	resourceGroup := id.ResourceGroup
+	subscriptionId := meta.(*ArmClient).subscriptionId
+	id := schedulerJobCollectionID(subscriptionId, resourceGroup, name)
 
-	log.Printf("[DEBUG] Deleting Scheduler Job Collection %q (resource group %q)", name, resourceGroup)
+	if d.Id() == "" && meta.(*ArmClient).requiresImport {
+		existing, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing Scheduler Job Collection %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+			}
+		}
 
-	future, err := client.Delete(ctx, resourceGroup, name)
-	if err != nil {
-		if !response.WasNotFound(future.Response()) {
-			return fmt.Errorf("Error issuing delete request for Scheduler Job Collection %q (Resource Group %q): %+v", name, resourceGroup, err)
+		if existing.ID != nil && *existing.ID != "" {
+			return importAsExistsError("azurerm_scheduler_job_collection", *existing.ID)
 		}
 	}
 
-	err = future.WaitForCompletion(ctx, client.Client)
-	if err != nil {
-		if !response.WasNotFound(future.Response()) {
-			return fmt.Errorf("Error waiting for deletion of Scheduler Job Collection %q (Resource Group %q): %+v", name, resourceGroup, err)
+	//the creation is validated during the plan - but this is checked again, since the Resource Group may not have been known
+	if d.Id() == "" && d.Get("validate_only").(bool) {
+		if err := validateSchedulerJobCollectionCreation(ctx, client, meta.(*ArmClient).providersClient, resourceGroup, name, location); err != nil {
+			return err
 		}
+
+		//no ID is set, so nothing is stored in the state and the Job Collection is planned for creation again
+		log.Printf("[INFO] Scheduler Job Collection %q (Resource Group %q) can be created, but hasn't been since `validate_only` is enabled - set `validate_only` to `false` to create it", name, resourceGroup)
+		return nil
 	}
 
-	return nil
-}
+	var collection scheduler.JobCollectionDefinition
+	var err error
 
-func expandAzureArmSchedulerJobCollectionQuota(d *schema.ResourceData) *scheduler.JobCollectionQuota {
-	if qb, ok := d.Get("quota").([]interface{}); ok && len(qb) > 0 {
-		quota := scheduler.JobCollectionQuota{
-			MaxRecurrence: &scheduler.JobMaxRecurrence{},
+	if d.Id() != "" && schedulerJobCollectionCanPatch(d) {
+		//only send the properties which have changed, so that (for example) updating the tags doesn't resend the SKU
+		cache.invalidate(id)
+		if schedulerJobCollectionPropertiesChanged(d) {
+			log.Printf("[DEBUG] Patching Scheduler Job Collection %q (resource group %q)", name, resourceGroup)
+			collection, err = patchSchedulerJobCollection(ctx, client, resourceGroup, name, expandSchedulerJobCollectionPatch(d), etag, additionalProperties)
 		}
 
-		quotaBlock := qb[0].(map[string]interface{})
+		//the tags are patched separately against the latest ETag, since tags are commonly added outside of Terraform
+		if err == nil && d.HasChange("tags") {
+			log.Printf("[DEBUG] Patching the tags of Scheduler Job Collection %q (resource group %q)", name, resourceGroup)
+			collection, err = updateSchedulerJobCollectionTags(ctx, client, resourceGroup, name, tags, removedSchedulerJobCollectionTagKeys(d))
+		}
+	} else {
+		collectionTags := expandTags(tags)
 
-		if v, ok := quotaBlock["max_job_count"].(int); ok {
-			quota.MaxJobCount = utils.Int32(int32(v))
+		//when recreating the collection in-place any tags which were added outside of Terraform need preserving
+		if d.Id() != "" {
+			existing, err := fetchSchedulerJobCollection(ctx, client, resourceGroup, name, "")
+			if err != nil {
+				return fmt.Errorf("Error reading current tags of Scheduler Job Collection %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+			}
+
+			collectionTags = mergeSchedulerJobCollectionTags(existing.collection.Tags, tags, removedSchedulerJobCollectionTagKeys(d))
 		}
-		if v, ok := quotaBlock["max_recurrence_frequency"].(string); ok {
-			quota.MaxRecurrence.Frequency = scheduler.RecurrenceFrequency(v)
+
+		collection = scheduler.JobCollectionDefinition{
+			Location: utils.String(location),
+			Tags:     collectionTags,
+			Properties: &scheduler.JobCollectionProperties{
+				Sku: &scheduler.Sku{
+					Name: scheduler.SkuDefinition(d.Get("sku").(string)),
+				},
+			},
 		}
-		if v, ok := quotaBlock["max_retry_interval"].(int); ok {
-			quota.MaxRecurrence.Interval = utils.Int32(int32(v))
+
+		if state, ok := d.Get("state").(string); ok {
+			collection.Properties.State = scheduler.JobCollectionState(state)
 		}
 
-		return &quota
+		//when external state changes are ignored leave the collection in whatever state it's currently in, unless the configured state changes
+		if d.Id() != "" && d.Get("ignore_external_state_changes").(bool) && !d.HasChange("state") {
+			existing, err := getSchedulerJobCollection(ctx, meta.(*ArmClient), id, resourceGroup, name)
+			if err != nil {
+				return fmt.Errorf("Error reading current state of Scheduler Job Collection %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+			}
+
+			if properties := existing.Properties; properties != nil {
+				log.Printf("[DEBUG] Ignoring external state changes - preserving state %q for Scheduler Job Collection %q (resource group %q)", properties.State, name, resourceGroup)
+				collection.Properties.State = properties.State
+			}
+		}
+		collection.Properties.Quota = expandAzureArmSchedulerJobCollectionQuota(d)
+
+		//create job collection
+		cache.invalidate(id)
+		collection, err = createOrUpdateSchedulerJobCollection(ctx, client, resourceGroup, name, collection, etag, additionalProperties)
+	}
+	if err != nil {
+		if response.WasPreconditionFailed(collection.Response.Response) {
+			return fmt.Errorf("Error updating Scheduler Job Collection %q (Resource Group %q): the resource was modified externally since it was last read, run `terraform refresh` and try again", name, resourceGroup)
+		}
+
+		if unavailableErr := schedulerServiceUnavailableError(err, location); unavailableErr != nil {
+			return fmt.Errorf("Error creating/updating Scheduler Job Collection %q (Resource Group %q): %+v", name, resourceGroup, unavailableErr)
+		}
+
+		return fmt.Errorf("Error creating/updating Scheduler Job Collection %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+	}
+
+	//a newly created collection has no state until it's been provisioned
+	if d.Id() == "" {
+		description := fmt.Sprintf("Scheduler Job Collection %q (Resource Group %q)", name, resourceGroup)
+		pending := []string{""}
+		target := []string{string(scheduler.Enabled), string(scheduler.Disabled), string(scheduler.Suspended)}
+		err = waitForProvisioningState(description, pending, target, schedulerJobCollectionProvisioningTimeout, func() (string, error) {
+			cache.invalidate(id)
+			existing, err := getSchedulerJobCollection(ctx, meta.(*ArmClient), id, resourceGroup, name)
+			if err != nil {
+				return "", fmt.Errorf("%s", formatARMError(err))
+			}
+
+			if existing.Properties == nil {
+				return "", nil
+			}
+
+			return string(existing.Properties.State), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	//ensure collection actually exists before building the ID
+	result, err := getSchedulerJobCollectionResult(ctx, meta.(*ArmClient), id, resourceGroup, name, "")
+	if err != nil {
+		return fmt.Errorf("Error reading Scheduler Job Collection %q after create/update (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+	}
+
+	d.SetId(normalizeResourceID(id))
+
+	result.collection.Tags = filterSchedulerJobCollectionManagedTags(result.collection.Tags, tags)
+	if err := resourceArmSchedulerJobCollectionPopulate(d, resourceGroup, &result.collection, meta.(*ArmClient).ignoreSystemTags); err != nil {
+		return err
+	}
+	d.Set("properties_json", result.rawProperties)
+
+	if err := resourceArmSchedulerJobCollectionUpdateSuspendedJobs(d, meta, resourceGroup, name); err != nil {
+		return err
+	}
+
+	return resourceArmSchedulerJobCollectionPopulateJobCount(d, meta, resourceGroup, name)
+}
+
+// resourceArmSchedulerJobCollectionUpdateSuspendedJobs disables the enabled Jobs within the Job Collection when
+// `suspend_jobs` is enabled, and re-enables only those Jobs when it's disabled
+func resourceArmSchedulerJobCollectionUpdateSuspendedJobs(d *schema.ResourceData, meta interface{}, resourceGroup, name string) error {
+	client := meta.(*ArmClient).schedulerJobsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	//the new value may be computed, so the Jobs which are currently suspended come from the state
+	old, _ := d.GetChange("suspended_jobs")
+	suspended := make([]string, 0)
+	for _, v := range old.(*schema.Set).List() {
+		suspended = append(suspended, v.(string))
+	}
+
+	if d.Get("suspend_jobs").(bool) {
+		if !d.HasChange("suspend_jobs") {
+			d.Set("suspended_jobs", suspended)
+			return nil
+		}
+
+		disabled, err := suspendSchedulerJobs(ctx, client, resourceGroup, name)
+
+		//any Jobs disabled before an error are tracked, so they're re-enabled later
+		d.Set("suspended_jobs", append(suspended, disabled...))
+		if err != nil {
+			//so that suspending the remaining Jobs is retried during the next apply
+			d.Set("suspend_jobs", false)
+			return fmt.Errorf("Error suspending the Jobs in Scheduler Job Collection %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+		}
+
+		return nil
+	}
+
+	remaining, err := resumeSchedulerJobs(ctx, client, resourceGroup, name, suspended)
+	d.Set("suspended_jobs", remaining)
+	if err != nil {
+		return fmt.Errorf("Error re-enabling the suspended Jobs in Scheduler Job Collection %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
 	}
 
 	return nil
 }
 
+// validateSchedulerJobCollectionCreation checks that the Job Collection could be created without creating it: that
+// the name isn't already in use within the Resource Group (which must exist), and that the Scheduler Resource Provider
+// is registered and available in the location.
+func validateSchedulerJobCollectionCreation(ctx context.Context, client scheduler.JobCollectionsClient, providersClient resources.ProvidersClient, resourceGroup, name, location string) error {
+	existing, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if serviceError := armServiceError(err); serviceError != nil && strings.EqualFold(serviceError.Code, "ResourceGroupNotFound") {
+			return fmt.Errorf("Error validating Scheduler Job Collection %q: Resource Group %q was not found", name, resourceGroup)
+		}
+
+		if !utils.ResponseWasNotFound(existing.Response) {
+			if unavailableErr := schedulerServiceUnavailableError(err, location); unavailableErr != nil {
+				return fmt.Errorf("Error validating Scheduler Job Collection %q (Resource Group %q): %+v", name, resourceGroup, unavailableErr)
+			}
+
+			return fmt.Errorf("Error checking for presence of existing Scheduler Job Collection %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+		}
+	}
+
+	if existing.ID != nil && *existing.ID != "" {
+		return fmt.Errorf("Error validating Scheduler Job Collection %q (Resource Group %q): the name is already in use by %q", name, resourceGroup, *existing.ID)
+	}
+
+	provider, err := providersClient.Get(ctx, "Microsoft.Scheduler", "")
+	if err != nil {
+		return fmt.Errorf("Error retrieving the Microsoft.Scheduler Resource Provider: %s", formatARMError(err))
+	}
+
+	if provider.RegistrationState == nil || !strings.EqualFold(*provider.RegistrationState, "Registered") {
+		return fmt.Errorf("Error validating Scheduler Job Collection %q (Resource Group %q): the Microsoft.Scheduler Resource Provider isn't registered in this Subscription", name, resourceGroup)
+	}
+
+	if !schedulerJobCollectionsAvailableIn(provider, location) {
+		return fmt.Errorf("Error validating Scheduler Job Collection %q (Resource Group %q): Azure Scheduler isn't available in the region %q since it's being retired - Azure Logic Apps should be used instead, see %s for how to migrate", name, resourceGroup, location, schedulerMigrationGuideURL)
+	}
+
+	return nil
+}
+
+// schedulerJobCollectionsAvailableIn returns whether Job Collections can be created in the location, according to the
+// Resource Provider - which returns the display names of the locations (e.g. `West Europe`)
+func schedulerJobCollectionsAvailableIn(provider resources.Provider, location string) bool {
+	if provider.ResourceTypes == nil {
+		return false
+	}
+
+	for _, resourceType := range *provider.ResourceTypes {
+		if resourceType.ResourceType == nil || !strings.EqualFold(*resourceType.ResourceType, "jobCollections") || resourceType.Locations == nil {
+			continue
+		}
+
+		for _, v := range *resourceType.Locations {
+			if azureRMNormalizeLocation(v) == azureRMNormalizeLocation(location) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func resourceArmSchedulerJobCollectionRead(d *schema.ResourceData, meta interface{}) error {
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	name := id.Path["jobCollections"]
+	resourceGroup := id.ResourceGroup
+
+	log.Printf("[DEBUG] Reading Scheduler Job Collection %q (resource group %q)", name, resourceGroup)
+
+	//only make a conditional request when the state has been fully populated by an earlier read, since
+	//otherwise (e.g. after importing, or upgrading the Provider) there may be fields which need populating
+	populated := d.Get("properties_json").(string) != ""
+	etag := ""
+	if populated {
+		etag = d.Get("etag").(string)
+	}
+
+	result, err := getSchedulerJobCollectionResult(ctx, meta.(*ArmClient), d.Id(), resourceGroup, name, etag)
+	if err != nil {
+		if utils.ResponseWasNotFound(result.collection.Response) {
+			d.SetId("")
+			return nil
+		}
+
+		if readWasForbidden(meta.(*ArmClient), result.collection.Response, fmt.Sprintf("Scheduler Job Collection %q (Resource Group %q)", name, resourceGroup)) {
+			return nil
+		}
+
+		return fmt.Errorf("Error making Read request on Scheduler Job Collection %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+	}
+
+	if result.notModified {
+		log.Printf("[DEBUG] Scheduler Job Collection %q (resource group %q) hasn't changed since it was last read (ETag %q)", name, resourceGroup, etag)
+		return resourceArmSchedulerJobCollectionPopulateJobCount(d, meta, resourceGroup, name)
+	}
+
+	//only the tags Terraform manages are tracked - other than when the state hasn't been populated yet (e.g. after importing)
+	if populated {
+		result.collection.Tags = filterSchedulerJobCollectionManagedTags(result.collection.Tags, d.Get("tags").(map[string]interface{}))
+	}
+
+	if err := resourceArmSchedulerJobCollectionPopulate(d, resourceGroup, &result.collection, meta.(*ArmClient).ignoreSystemTags); err != nil {
+		return err
+	}
+	d.Set("properties_json", result.rawProperties)
+
+	return resourceArmSchedulerJobCollectionPopulateJobCount(d, meta, resourceGroup, name)
+}
+
+func resourceArmSchedulerJobCollectionPopulateJobCount(d *schema.ResourceData, meta interface{}, resourceGroup, name string) error {
+	if !d.Get("include_job_count").(bool) {
+		return nil
+	}
+
+	client := meta.(*ArmClient).schedulerJobsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	count, err := countSchedulerJobs(ctx, client, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error counting the Jobs in Scheduler Job Collection %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+	}
+
+	d.Set("job_count", count)
+
+	return nil
+}
+
+// countSchedulerJobs returns the number of Jobs within the Job Collection, across all pages of results
+func countSchedulerJobs(ctx context.Context, client scheduler.JobsClient, resourceGroup, name string) (int, error) {
+	results, err := client.ListComplete(ctx, resourceGroup, name, nil, nil, "")
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for results.NotDone() {
+		count++
+
+		if err := results.Next(); err != nil {
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
+// suspendSchedulerJobs disables each of the enabled Jobs within the Job Collection, returning the names of the Jobs which
+// were disabled - Jobs which are already disabled (or have faulted/completed) are left as-is so they aren't re-enabled later.
+// When an error occurs the Jobs disabled up to that point are also returned.
+func suspendSchedulerJobs(ctx context.Context, client scheduler.JobsClient, resourceGroup, collectionName string) ([]string, error) {
+	disabled := make([]string, 0)
+
+	results, err := client.ListComplete(ctx, resourceGroup, collectionName, nil, nil, "")
+	if err != nil {
+		return disabled, err
+	}
+
+	enabled := make([]string, 0)
+	for results.NotDone() {
+		job := results.Value()
+		if job.Name != nil && job.Properties != nil && job.Properties.State == scheduler.JobStateEnabled {
+			enabled = append(enabled, schedulerJobName(job.Name))
+		}
+
+		if err := results.Next(); err != nil {
+			return disabled, err
+		}
+	}
+
+	for _, jobName := range enabled {
+		log.Printf("[DEBUG] Disabling Job %q in Scheduler Job Collection %q (resource group %q)", jobName, collectionName, resourceGroup)
+		if err := setSchedulerJobState(ctx, client, resourceGroup, collectionName, jobName, scheduler.JobStateDisabled); err != nil {
+			return disabled, err
+		}
+
+		disabled = append(disabled, jobName)
+	}
+
+	return disabled, nil
+}
+
+// resumeSchedulerJobs re-enables each of the specified Jobs which are still disabled - Jobs which have since been deleted,
+// or re-enabled outside of Terraform, are skipped. This returns the Jobs which are still suspended, which is only the case
+// when an error occurs.
+func resumeSchedulerJobs(ctx context.Context, client scheduler.JobsClient, resourceGroup, collectionName string, jobNames []string) ([]string, error) {
+	for i, jobName := range jobNames {
+		job, err := client.Get(ctx, resourceGroup, collectionName, jobName)
+		if err != nil {
+			if utils.ResponseWasNotFound(job.Response) {
+				log.Printf("[DEBUG] Suspended Job %q in Scheduler Job Collection %q (resource group %q) no longer exists - skipping", jobName, collectionName, resourceGroup)
+				continue
+			}
+
+			return jobNames[i:], err
+		}
+
+		if job.Properties == nil || job.Properties.State != scheduler.JobStateDisabled {
+			log.Printf("[DEBUG] Suspended Job %q in Scheduler Job Collection %q (resource group %q) is no longer disabled - skipping", jobName, collectionName, resourceGroup)
+			continue
+		}
+
+		log.Printf("[DEBUG] Enabling Job %q in Scheduler Job Collection %q (resource group %q)", jobName, collectionName, resourceGroup)
+		if err := setSchedulerJobState(ctx, client, resourceGroup, collectionName, jobName, scheduler.JobStateEnabled); err != nil {
+			return jobNames[i:], err
+		}
+	}
+
+	return []string{}, nil
+}
+
+func setSchedulerJobState(ctx context.Context, client scheduler.JobsClient, resourceGroup, collectionName, jobName string, state scheduler.JobState) error {
+	job := scheduler.JobDefinition{
+		Properties: &scheduler.JobProperties{
+			State: state,
+		},
+	}
+
+	_, err := client.Patch(ctx, resourceGroup, collectionName, jobName, job)
+	return err
+}
+
+// schedulerJobName returns the name of a Job, which the API returns in the format `collectionName/jobName`
+func schedulerJobName(input *string) string {
+	if input == nil {
+		return ""
+	}
+
+	segments := strings.Split(*input, "/")
+	return segments[len(segments)-1]
+}
+
+func resourceArmSchedulerJobCollectionPopulate(d *schema.ResourceData, resourceGroup string, collection *scheduler.JobCollectionDefinition, ignoreSystemTags bool) error {
+
+	//standard properties
+	d.Set("name", collection.Name)
+	d.Set("location", azureRMNormalizeLocation(*collection.Location))
+	d.Set("resource_group_name", resourceGroup)
+	flattenAndSetTagsIgnoringSystemTags(d, collection.Tags, ignoreSystemTags)
+
+	//resource specific
+	if properties := collection.Properties; properties != nil {
+		if sku := properties.Sku; sku != nil {
+			d.Set("sku", sku.Name)
+		}
+
+		//the configured state is kept when external state changes are ignored, so Terraform doesn't revert them
+		if !d.Get("ignore_external_state_changes").(bool) {
+			d.Set("state", string(properties.State))
+		}
+
+		quota := flattenAzureArmSchedulerJobCollectionQuota(properties.Quota)
+
+		//the deprecated `quota` block is only populated when it's being used, so it doesn't conflict with the top-level fields
+		if qb := d.Get("quota").([]interface{}); len(qb) > 0 {
+			quotaBlock := quota
+
+			//an empty block is treated as no quota, so is kept empty to match the configuration
+			if _, ok := schedulerJobCollectionQuotaBlock(qb); !ok {
+				quotaBlock = []interface{}{map[string]interface{}{}}
+			}
+
+			if err := d.Set("quota", quotaBlock); err != nil {
+				return fmt.Errorf("Error flattening quota for Job Collection %q (Resource Group %q): %+v", d.Get("name").(string), resourceGroup, err)
+			}
+		}
+
+		//the top-level fields aren't populated whilst the deprecated `quota` block is being used, otherwise removing the
+		//block would send the quota again rather than removing it
+		maxJobCount, maxRecurrenceFrequency, maxRetryInterval := 0, "", 0
+		if q := properties.Quota; q != nil && len(d.Get("quota").([]interface{})) == 0 {
+			if q.MaxJobCount != nil {
+				maxJobCount = int(*q.MaxJobCount)
+			}
+			if r := q.MaxRecurrence; r != nil {
+				maxRecurrenceFrequency = string(r.Frequency)
+				if r.Interval != nil {
+					maxRetryInterval = int(*r.Interval)
+				}
+			}
+		}
+		d.Set("max_job_count", maxJobCount)
+		d.Set("max_recurrence_frequency", maxRecurrenceFrequency)
+		d.Set("max_retry_interval", maxRetryInterval)
+
+		if err := d.Set("effective_quota", quota); err != nil {
+			return fmt.Errorf("Error flattening effective quota for Job Collection %q (Resource Group %q): %+v", d.Get("name").(string), resourceGroup, err)
+		}
+	}
+
+	//the etag isn't part of the model, but is returned as a header
+	if resp := collection.Response.Response; resp != nil {
+		d.Set("etag", resp.Header.Get("ETag"))
+	}
+
+	return nil
+}
+
+func resourceArmSchedulerJobCollectionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).schedulerJobCollectionsClient
+	cache := meta.(*ArmClient).schedulerJobCollectionsCache
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	name := id.Path["jobCollections"]
+	resourceGroup := id.ResourceGroup
+
+	log.Printf("[DEBUG] Deleting Scheduler Job Collection %q (resource group %q)", name, resourceGroup)
+	cache.invalidate(d.Id())
+
+	return deleteSchedulerJobCollection(ctx, client, resourceGroup, name, d.Get("force_delete").(bool))
+}
+
+// deleteSchedulerJobCollection deletes the Job Collection - when `forceDelete` is set this returns once the
+// deletion has been accepted, rather than waiting for it to complete.
+func deleteSchedulerJobCollection(ctx context.Context, client scheduler.JobCollectionsClient, resourceGroup, name string, forceDelete bool) error {
+	future, err := client.Delete(ctx, resourceGroup, name)
+	if err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error issuing delete request for Scheduler Job Collection %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+		}
+	}
+
+	if forceDelete {
+		log.Printf("[DEBUG] `force_delete` is enabled - not waiting for deletion of Scheduler Job Collection %q (resource group %q) to complete", name, resourceGroup)
+		return nil
+	}
+
+	description := fmt.Sprintf("the deletion of Scheduler Job Collection %q (Resource Group %q)", name, resourceGroup)
+	err = waitForCompletionRetryingOnTransientErrors(ctx, description, future, client.Client, schedulerJobCollectionDeleteTimeout)
+	if err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for deletion of Scheduler Job Collection %q (Resource Group %q): %s", name, resourceGroup, formatARMError(err))
+		}
+	}
+
+	return nil
+}
+
+// getSchedulerJobCollection retrieves the Job Collection, reusing the response from an earlier
+// read in this run (e.g. create-then-read) when it's still in the cache.
+func getSchedulerJobCollection(ctx context.Context, client *ArmClient, id, resourceGroup, name string) (scheduler.JobCollectionDefinition, error) {
+	result, err := getSchedulerJobCollectionResult(ctx, client, id, resourceGroup, name, "")
+	return result.collection, err
+}
+
+// schedulerJobCollectionResult is a Job Collection retrieved from the API, along with the raw JSON of its properties
+// - which includes any properties the SDK doesn't (yet) model
+type schedulerJobCollectionResult struct {
+	collection    scheduler.JobCollectionDefinition
+	rawProperties string
+
+	// notModified is whether the Job Collection hasn't changed since the ETag used for a conditional request,
+	// in which case the collection isn't returned
+	notModified bool
+}
+
+// getSchedulerJobCollectionResult retrieves the Job Collection, using the cache when possible - when an `etag` is
+// specified the request is conditional, so the result may instead be that the Job Collection is `notModified`
+func getSchedulerJobCollectionResult(ctx context.Context, client *ArmClient, id, resourceGroup, name, etag string) (schedulerJobCollectionResult, error) {
+	if cached, ok := client.schedulerJobCollectionsCache.get(id); ok {
+		log.Printf("[DEBUG] Using cached Scheduler Job Collection %q (resource group %q)", name, resourceGroup)
+		return cached.(schedulerJobCollectionResult), nil
+	}
+
+	result, err := fetchSchedulerJobCollection(ctx, client.schedulerJobCollectionsClient, resourceGroup, name, etag)
+	if err != nil {
+		return result, err
+	}
+
+	if result.notModified {
+		return result, nil
+	}
+
+	client.schedulerJobCollectionsCache.set(id, result)
+	return result, nil
+}
+
+// fetchSchedulerJobCollection is equivalent to the SDK's Get, but also keeps the raw JSON of the properties
+// returned from the API, which the SDK discards when unmarshalling the response.
+//
+// When an `etag` is specified it's sent as an If-None-Match header, so the API can return a 304 when the Job
+// Collection hasn't changed - should the API not support this a 200 is returned, which is handled as usual.
+func fetchSchedulerJobCollection(ctx context.Context, client scheduler.JobCollectionsClient, resourceGroup, name, etag string) (schedulerJobCollectionResult, error) {
+	result := schedulerJobCollectionResult{}
+
+	req, err := client.GetPreparer(ctx, resourceGroup, name)
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "scheduler.JobCollectionsClient", "Get", nil, "Failure preparing request")
+	}
+
+	if etag != "" {
+		req, err = autorest.Prepare(req, autorest.WithHeader("If-None-Match", etag))
+		if err != nil {
+			return result, autorest.NewErrorWithError(err, "scheduler.JobCollectionsClient", "Get", nil, "Failure preparing request")
+		}
+	}
+
+	resp, err := client.GetSender(req)
+	if err != nil {
+		result.collection.Response = autorest.Response{Response: resp}
+		return result, autorest.NewErrorWithError(err, "scheduler.JobCollectionsClient", "Get", resp, "Failure sending request")
+	}
+
+	if etag != "" && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		result.collection.Response = autorest.Response{Response: resp}
+		result.notModified = true
+		return result, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		result.collection.Response = autorest.Response{Response: resp}
+		return result, autorest.NewErrorWithError(err, "scheduler.JobCollectionsClient", "Get", resp, "Failure reading response")
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	result.collection, err = client.GetResponder(resp)
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "scheduler.JobCollectionsClient", "Get", resp, "Failure responding to request")
+	}
+
+	var raw struct {
+		Properties json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(body, &raw); err == nil && len(raw.Properties) > 0 {
+		result.rawProperties = string(raw.Properties)
+	}
+
+	return result, nil
+}
+
+// schedulerJobCollectionCanPatch returns whether the changes to the Job Collection can be applied using a PATCH
+// request, which doesn't support changing the SKU or removing the quota - these require a full CreateOrUpdate.
+func schedulerJobCollectionCanPatch(d *schema.ResourceData) bool {
+	if d.HasChange("sku") {
+		return false
+	}
+
+	if _, ok := schedulerJobCollectionQuotaBlock(d.Get("quota").([]interface{})); d.HasChange("quota") && !ok {
+		return false
+	}
+
+	return true
+}
+
+// schedulerJobCollectionPropertiesChanged returns whether any of the properties of the Job Collection (rather than
+// the tags, or the Terraform-only fields) have changed
+func schedulerJobCollectionPropertiesChanged(d *schema.ResourceData) bool {
+	for _, key := range []string{"state", "quota", "max_job_count", "max_recurrence_frequency", "max_retry_interval", "additional_properties_json"} {
+		if d.HasChange(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expandSchedulerJobCollectionPatch returns a Job Collection containing only the properties which have changed
+func expandSchedulerJobCollectionPatch(d *schema.ResourceData) scheduler.JobCollectionDefinition {
+	collection := scheduler.JobCollectionDefinition{
+		Properties: &scheduler.JobCollectionProperties{},
+	}
+
+	if d.HasChange("state") {
+		collection.Properties.State = scheduler.JobCollectionState(d.Get("state").(string))
+	}
+
+	if d.HasChange("quota") || d.HasChange("max_job_count") || d.HasChange("max_recurrence_frequency") || d.HasChange("max_retry_interval") {
+		collection.Properties.Quota = expandAzureArmSchedulerJobCollectionQuota(d)
+	}
+
+	return collection
+}
+
+// patchSchedulerJobCollection calls Patch on the Job Collection, sending the ETag (when specified) as an If-Match header
+func patchSchedulerJobCollection(ctx context.Context, client scheduler.JobCollectionsClient, resourceGroup, name string, collection scheduler.JobCollectionDefinition, etag, additionalProperties string) (scheduler.JobCollectionDefinition, error) {
+	req, err := client.PatchPreparer(ctx, resourceGroup, name, collection)
+	if err != nil {
+		return scheduler.JobCollectionDefinition{}, autorest.NewErrorWithError(err, "scheduler.JobCollectionsClient", "Patch", nil, "Failure preparing request")
+	}
+
+	req, err = prepareSchedulerJobCollectionRequest(req, etag, additionalProperties)
+	if err != nil {
+		return scheduler.JobCollectionDefinition{}, autorest.NewErrorWithError(err, "scheduler.JobCollectionsClient", "Patch", nil, "Failure preparing request")
+	}
+
+	resp, err := client.PatchSender(req)
+	if err != nil {
+		return scheduler.JobCollectionDefinition{Response: autorest.Response{Response: resp}}, autorest.NewErrorWithError(err, "scheduler.JobCollectionsClient", "Patch", resp, "Failure sending request")
+	}
+
+	result, err := client.PatchResponder(resp)
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "scheduler.JobCollectionsClient", "Patch", resp, "Failure responding to request")
+	}
+
+	return result, nil
+}
+
+// updateSchedulerJobCollectionTags patches the tags of the Job Collection, applying the configured tags and removing
+// the managed tags which are no longer configured to the latest tags - preserving any tags added outside of Terraform.
+//
+// Since a Patch replaces all of the tags, the latest ETag is sent as an If-Match header - should the tags be changed
+// between reading and patching them, they're read again and the update retried. Requests which fail temporarily
+// (see `armErrorIsRetriable`) are also retried, once the `Retry-After` interval has elapsed.
+func updateSchedulerJobCollectionTags(ctx context.Context, client scheduler.JobCollectionsClient, resourceGroup, name string, tags map[string]interface{}, removed []string) (scheduler.JobCollectionDefinition, error) {
+	description := fmt.Sprintf("the tags of Scheduler Job Collection %q (resource group %q) to be updated again", name, resourceGroup)
+
+	for attempt := 1; ; attempt++ {
+		existing, err := fetchSchedulerJobCollection(ctx, client, resourceGroup, name, "")
+		if err != nil {
+			if !armErrorIsRetriable(err) || attempt == schedulerJobCollectionTagsUpdateAttempts {
+				return existing.collection, err
+			}
+
+			log.Printf("[DEBUG] Retrieving the tags of Scheduler Job Collection %q (resource group %q) failed temporarily - retrying (attempt %d of %d): %+v", name, resourceGroup, attempt, schedulerJobCollectionTagsUpdateAttempts, err)
+			if err := waitForDelay(ctx, description, retryAfterFromResponse(armErrorResponse(err))); err != nil {
+				return existing.collection, err
+			}
+			continue
+		}
+
+		etag := ""
+		if resp := existing.collection.Response.Response; resp != nil {
+			etag = resp.Header.Get("ETag")
+		}
+
+		patch := scheduler.JobCollectionDefinition{
+			Tags: mergeSchedulerJobCollectionTags(existing.collection.Tags, tags, removed),
+		}
+
+		collection, err := patchSchedulerJobCollection(ctx, client, resourceGroup, name, patch, etag, "")
+		if err == nil || attempt == schedulerJobCollectionTagsUpdateAttempts {
+			return collection, err
+		}
+
+		if response.WasPreconditionFailed(collection.Response.Response) {
+			log.Printf("[DEBUG] Tags of Scheduler Job Collection %q (resource group %q) were modified whilst updating them - retrying (attempt %d of %d)", name, resourceGroup, attempt, schedulerJobCollectionTagsUpdateAttempts)
+			continue
+		}
+
+		if !armErrorIsRetriable(err) {
+			return collection, err
+		}
+
+		log.Printf("[DEBUG] Updating the tags of Scheduler Job Collection %q (resource group %q) failed temporarily - retrying (attempt %d of %d): %+v", name, resourceGroup, attempt, schedulerJobCollectionTagsUpdateAttempts, err)
+		if err := waitForDelay(ctx, description, retryAfterFromResponse(armErrorResponse(err))); err != nil {
+			return collection, err
+		}
+	}
+}
+
+// mergeSchedulerJobCollectionTags returns the `existing` tags with the configured `tags` applied and the `removed`
+// tags deleted. Tag names are case-insensitive, so a configured tag replaces an existing tag with a different casing.
+func mergeSchedulerJobCollectionTags(existing *map[string]*string, tags map[string]interface{}, removed []string) *map[string]*string {
+	output := make(map[string]*string)
+	if existing != nil {
+		for k, v := range *existing {
+			output[k] = v
+		}
+	}
+
+	deleteTag := func(key string) {
+		for k := range output {
+			if strings.EqualFold(k, key) {
+				delete(output, k)
+			}
+		}
+	}
+
+	for _, key := range removed {
+		deleteTag(key)
+	}
+
+	for k, v := range *expandTags(tags) {
+		deleteTag(k)
+		output[k] = v
+	}
+
+	return &output
+}
+
+// removedSchedulerJobCollectionTagKeys returns the names of the tags which were managed by Terraform but have since
+// been removed from the configuration
+func removedSchedulerJobCollectionTagKeys(d *schema.ResourceData) []string {
+	old, new := d.GetChange("tags")
+	configured := new.(map[string]interface{})
+
+	removed := make([]string, 0)
+	for k := range old.(map[string]interface{}) {
+		if !schedulerJobCollectionTagConfigured(configured, k) {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(removed)
+
+	return removed
+}
+
+// filterSchedulerJobCollectionManagedTags returns only the tags which are managed by Terraform (those configured in
+// `managed`), so that tags added outside of Terraform don't show a diff - the casing of the returned tag is preserved
+func filterSchedulerJobCollectionManagedTags(input *map[string]*string, managed map[string]interface{}) *map[string]*string {
+	if input == nil {
+		return nil
+	}
+
+	output := make(map[string]*string)
+	for k, v := range *input {
+		if schedulerJobCollectionTagConfigured(managed, k) {
+			output[k] = v
+		}
+	}
+
+	return &output
+}
+
+func schedulerJobCollectionTagConfigured(tags map[string]interface{}, key string) bool {
+	for k := range tags {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// createOrUpdateSchedulerJobCollection calls CreateOrUpdate on the Job Collection, sending the ETag
+// (when specified) as an If-Match header so the API rejects the request if the collection has changed.
+//
+// NOTE: Jobs are child resources and aren't part of the Job Collection model, so a CreateOrUpdate doesn't
+// remove any Jobs within the collection (including those created outside of Terraform). This is checked by
+// TestSchedulerJobCollectionDefinitionDoesNotManageJobs, which fails should the SDK model change.
+func createOrUpdateSchedulerJobCollection(ctx context.Context, client scheduler.JobCollectionsClient, resourceGroup, name string, collection scheduler.JobCollectionDefinition, etag, additionalProperties string) (scheduler.JobCollectionDefinition, error) {
+	if etag == "" && additionalProperties == "" {
+		return client.CreateOrUpdate(ctx, resourceGroup, name, collection)
+	}
+
+	req, err := client.CreateOrUpdatePreparer(ctx, resourceGroup, name, collection)
+	if err != nil {
+		return scheduler.JobCollectionDefinition{}, err
+	}
+
+	req, err = prepareSchedulerJobCollectionRequest(req, etag, additionalProperties)
+	if err != nil {
+		return scheduler.JobCollectionDefinition{}, err
+	}
+
+	resp, err := client.CreateOrUpdateSender(req)
+	if err != nil {
+		return scheduler.JobCollectionDefinition{Response: autorest.Response{Response: resp}}, err
+	}
+
+	return client.CreateOrUpdateResponder(resp)
+}
+
+// prepareSchedulerJobCollectionRequest merges the `additionalProperties` JSON (when specified) into the properties
+// in the request body, and sends the ETag (when specified) as an If-Match header
+func prepareSchedulerJobCollectionRequest(req *http.Request, etag, additionalProperties string) (*http.Request, error) {
+	if additionalProperties != "" {
+		var additional map[string]interface{}
+		if err := json.Unmarshal([]byte(additionalProperties), &additional); err != nil {
+			return req, fmt.Errorf("Error parsing `additional_properties_json`: %+v", err)
+		}
+
+		body := make(map[string]interface{})
+		if req.Body != nil {
+			b, err := ioutil.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return req, err
+			}
+
+			if err := json.Unmarshal(b, &body); err != nil {
+				return req, err
+			}
+		}
+
+		properties, ok := body["properties"].(map[string]interface{})
+		if !ok {
+			properties = make(map[string]interface{})
+		}
+		body["properties"] = mergeSchedulerJobCollectionProperties(properties, additional)
+
+		var err error
+		req, err = autorest.Prepare(req, autorest.WithJSON(body))
+		if err != nil {
+			return req, err
+		}
+	}
+
+	if etag != "" {
+		return autorest.Prepare(req, autorest.WithHeader("If-Match", etag))
+	}
+
+	return req, nil
+}
+
+// mergeSchedulerJobCollectionProperties merges the `additional` properties into the `explicit` properties - where a
+// property is present in both the explicit value takes precedence, with nested objects being merged recursively
+func mergeSchedulerJobCollectionProperties(explicit, additional map[string]interface{}) map[string]interface{} {
+	for k, v := range additional {
+		existing, ok := explicit[k]
+		if !ok {
+			explicit[k] = v
+			continue
+		}
+
+		existingObject, existingIsObject := existing.(map[string]interface{})
+		additionalObject, additionalIsObject := v.(map[string]interface{})
+		if existingIsObject && additionalIsObject {
+			explicit[k] = mergeSchedulerJobCollectionProperties(existingObject, additionalObject)
+		}
+	}
+
+	return explicit
+}
+
+// resourceArmSchedulerJobCollectionImport allows importing using either the full Resource ID
+// or the shorthand `resourceGroup/collectionName`, using the Provider's Subscription ID.
+func resourceArmSchedulerJobCollectionImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	subscriptionId := meta.(*ArmClient).subscriptionId
+
+	id, err := expandSchedulerJobCollectionImportID(d.Id(), subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+
+	//there's no configuration during import so the defaults of these Terraform-only fields need setting, otherwise the next plan shows a diff
+	d.Set("ignore_external_state_changes", false)
+	d.Set("force_delete", false)
+	d.Set("include_job_count", false)
+	d.Set("suspend_jobs", false)
+	d.Set("validate_only", false)
+	d.Set("error_on_free_sku_quota", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandSchedulerJobCollectionImportID(input, subscriptionId string) (string, error) {
+	//IDs are often generated when importing Job Collections in bulk, so any surrounding whitespace is ignored
+	input = strings.TrimSpace(input)
+
+	if strings.HasPrefix(input, "/") {
+		id, err := parseAzureResourceID(input)
+		if err != nil {
+			return "", err
+		}
+
+		//some APIs return the segments in a different casing, however the ID of a nested resource (e.g. a Job) isn't valid
+		name := ""
+		for key, value := range id.Path {
+			if !strings.EqualFold(key, "jobCollections") {
+				name = ""
+				break
+			}
+			name = value
+		}
+
+		if name == "" || !strings.EqualFold(id.Provider, "Microsoft.Scheduler") {
+			return "", fmt.Errorf("Error parsing supplied resource id. Please check it and rerun:\n %s", input)
+		}
+
+		return schedulerJobCollectionID(id.SubscriptionID, id.ResourceGroup, name), nil
+	}
+
+	segments := strings.Split(input, "/")
+	if len(segments) != 2 || segments[0] == "" || segments[1] == "" {
+		return "", fmt.Errorf("Expected the ID to be either a Resource ID or in the format `resourceGroup/collectionName` but got %q", input)
+	}
+
+	return schedulerJobCollectionID(subscriptionId, segments[0], segments[1]), nil
+}
+
+// schedulerJobCollectionID returns the canonical Resource ID for a Scheduler Job Collection,
+// which can also be used when importing an existing Job Collection.
+func schedulerJobCollectionID(subscriptionId, resourceGroup, name string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Scheduler/jobCollections/%s", subscriptionId, resourceGroup, name)
+}
+
+func expandAzureArmSchedulerJobCollectionQuota(d *schema.ResourceData) *scheduler.JobCollectionQuota {
+	if quotaBlock, ok := schedulerJobCollectionQuotaBlock(d.Get("quota").([]interface{})); ok {
+		quota := scheduler.JobCollectionQuota{
+			MaxRecurrence: &scheduler.JobMaxRecurrence{},
+		}
+
+		if v, ok := quotaBlock["max_job_count"].(int); ok {
+			quota.MaxJobCount = utils.Int32(int32(v))
+		}
+		if v, ok := quotaBlock["max_recurrence_frequency"].(string); ok {
+			quota.MaxRecurrence.Frequency = scheduler.RecurrenceFrequency(v)
+		}
+		if v, ok := quotaBlock["max_retry_interval"].(int); ok {
+			quota.MaxRecurrence.Interval = utils.Int32(int32(v))
+		}
+
+		return &quota
+	}
+
+	maxJobCount, hasMaxJobCount := d.GetOk("max_job_count")
+	maxRecurrenceFrequency, hasMaxRecurrenceFrequency := d.GetOk("max_recurrence_frequency")
+	maxRetryInterval, hasMaxRetryInterval := d.GetOk("max_retry_interval")
+
+	if !hasMaxJobCount && !hasMaxRecurrenceFrequency && !hasMaxRetryInterval {
+		return nil
+	}
+
+	quota := scheduler.JobCollectionQuota{}
+	if hasMaxJobCount {
+		quota.MaxJobCount = utils.Int32(int32(maxJobCount.(int)))
+	}
+
+	//the API rejects a recurrence without a frequency, so the retry interval alone isn't sent
+	if hasMaxRecurrenceFrequency {
+		quota.MaxRecurrence = &scheduler.JobMaxRecurrence{
+			Frequency: scheduler.RecurrenceFrequency(maxRecurrenceFrequency.(string)),
+		}
+		if hasMaxRetryInterval {
+			quota.MaxRecurrence.Interval = utils.Int32(int32(maxRetryInterval.(int)))
+		}
+	}
+
+	return &quota
+}
+
 func flattenAzureArmSchedulerJobCollectionQuota(quota *scheduler.JobCollectionQuota) []interface{} {
 
 	if quota == nil {
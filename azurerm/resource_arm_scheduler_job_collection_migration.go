@@ -0,0 +1,43 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func resourceAzureRMSchedulerJobCollectionMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Println("[INFO] Found AzureRM Scheduler Job Collection State v0; migrating to v1")
+		return migrateAzureRMSchedulerJobCollectionStateV0toV1(is)
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+}
+
+func migrateAzureRMSchedulerJobCollectionStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	log.Printf("[DEBUG] ARM Scheduler Job Collection Attributes before Migration: %#v", is.Attributes)
+
+	// the `quota` block has been flattened into top-level fields - the block itself is retained
+	// since it's deprecated rather than removed, and may still be present in the configuration
+	if is.Attributes["quota.#"] == "1" {
+		for _, field := range []string{"max_job_count", "max_recurrence_frequency", "max_retry_interval"} {
+			if v, ok := is.Attributes["quota.0."+field]; ok {
+				if _, exists := is.Attributes[field]; !exists {
+					is.Attributes[field] = v
+				}
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] ARM Scheduler Job Collection Attributes after State Migration: %#v", is.Attributes)
+
+	return is, nil
+}
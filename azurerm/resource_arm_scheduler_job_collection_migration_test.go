@@ -0,0 +1,91 @@
+package azurerm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAzureRMSchedulerJobCollectionMigrateState(t *testing.T) {
+	cases := map[string]struct {
+		StateVersion int
+		ID           string
+		Attributes   map[string]string
+		Expected     map[string]string
+		Meta         interface{}
+	}{
+		"v0_1_empty": {
+			StateVersion: 0,
+			ID:           "some_id",
+			Attributes:   map[string]string{},
+			Expected:     map[string]string{},
+		},
+		"v0_1_without_quota": {
+			StateVersion: 0,
+			ID:           "some_id",
+			Attributes: map[string]string{
+				"name":    "collection1",
+				"sku":     "Standard",
+				"quota.#": "0",
+			},
+			Expected: map[string]string{
+				"name":    "collection1",
+				"sku":     "Standard",
+				"quota.#": "0",
+			},
+		},
+		"v0_1_with_quota": {
+			StateVersion: 0,
+			ID:           "some_id",
+			Attributes: map[string]string{
+				"name":                             "collection1",
+				"sku":                              "Standard",
+				"quota.#":                          "1",
+				"quota.0.max_job_count":            "10",
+				"quota.0.max_recurrence_frequency": "hour",
+				"quota.0.max_retry_interval":       "5",
+			},
+			Expected: map[string]string{
+				"name":                             "collection1",
+				"sku":                              "Standard",
+				"quota.#":                          "1",
+				"quota.0.max_job_count":            "10",
+				"quota.0.max_recurrence_frequency": "hour",
+				"quota.0.max_retry_interval":       "5",
+				"max_job_count":                    "10",
+				"max_recurrence_frequency":         "hour",
+				"max_retry_interval":               "5",
+			},
+		},
+		"v0_1_partial_quota": {
+			StateVersion: 0,
+			ID:           "some_id",
+			Attributes: map[string]string{
+				"quota.#":                          "1",
+				"quota.0.max_recurrence_frequency": "Day",
+			},
+			Expected: map[string]string{
+				"quota.#":                          "1",
+				"quota.0.max_recurrence_frequency": "Day",
+				"max_recurrence_frequency":         "Day",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		is := &terraform.InstanceState{
+			ID:         tc.ID,
+			Attributes: tc.Attributes,
+		}
+		is, err := resourceAzureRMSchedulerJobCollectionMigrateState(tc.StateVersion, is, tc.Meta)
+
+		if err != nil {
+			t.Fatalf("bad: %q, err: %+v", tn, err)
+		}
+
+		if !reflect.DeepEqual(tc.Expected, is.Attributes) {
+			t.Fatalf("Bad Scheduler Job Collection Migrate %q\n\n. Got: %+v\n\n expected: %+v", tn, is.Attributes, tc.Expected)
+		}
+	}
+}
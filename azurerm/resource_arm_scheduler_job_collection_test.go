@@ -1,21 +1,2039 @@
 package azurerm
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2017-05-10/resources"
 	"github.com/Azure/azure-sdk-for-go/services/scheduler/mgmt/2016-03-01/scheduler"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func init() {
+	resource.AddTestSweepers("azurerm_scheduler_job_collection", &resource.Sweeper{
+		Name: "azurerm_scheduler_job_collection",
+		F:    testSweepSchedulerJobCollections,
+	})
+}
+
+func testSweepSchedulerJobCollections(region string) error {
+	armClient, err := buildConfigForSweepers()
+	if err != nil {
+		return err
+	}
+
+	client := armClient.schedulerJobCollectionsClient
+	ctx := armClient.StopContext
+
+	log.Printf("Retrieving the Scheduler Job Collections..")
+	results, err := client.ListBySubscriptionComplete(ctx)
+	if err != nil {
+		return fmt.Errorf("Error Listing on Scheduler Job Collections: %+v", err)
+	}
+
+	for results.NotDone() {
+		collection := results.Value()
+		if err := results.Next(); err != nil {
+			return fmt.Errorf("Error retrieving the next page of Scheduler Job Collections: %+v", err)
+		}
+
+		if collection.Name == nil || collection.Location == nil || collection.ID == nil {
+			continue
+		}
+
+		if !shouldSweepAcceptanceTestResource(*collection.Name, *collection.Location, region) {
+			continue
+		}
+
+		resourceId, err := parseAzureResourceID(*collection.ID)
+		if err != nil {
+			return err
+		}
+
+		resourceGroup := resourceId.ResourceGroup
+		name := resourceId.Path["jobCollections"]
+
+		//only remove collections from the Resource Groups created by the acceptance tests
+		if !strings.HasPrefix(strings.ToLower(resourceGroup), "acctestrg-") {
+			log.Printf("Ignoring Scheduler Job Collection %q as Resource Group %q doesn't start with `acctestRG-`", name, resourceGroup)
+			continue
+		}
+
+		log.Printf("Deleting Scheduler Job Collection %q in Resource Group %q", name, resourceGroup)
+		if err := deleteSchedulerJobCollection(ctx, client, resourceGroup, name, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestValidateSchedulerJobCollectionMaxRecurrence(t *testing.T) {
+	testCases := []struct {
+		frequency   string
+		interval    int
+		shouldError bool
+	}{
+		{"", 0, false},
+		{"Minute", 0, false},
+		{"Minute", 1, false},
+		{"Minute", 72000, false},
+		{"Minute", 72001, true},
+		{"hour", 12000, false},
+		{"hour", 12001, true},
+		{"Day", 500, false},
+		{"Day", 501, true},
+		{"Week", 71, false},
+		{"Week", 72, true},
+		{"Month", 16, false},
+		{"month", 17, true},
+	}
+
+	for _, test := range testCases {
+		err := validateSchedulerJobCollectionMaxRecurrence(test.frequency, test.interval)
+
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected validating %d %q to fail", test.interval, test.frequency)
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected validating %d %q not to fail: %+v", test.interval, test.frequency, err)
+		}
+	}
+}
+
+func TestCheckSchedulerJobCollectionFreeSkuQuota(t *testing.T) {
+	testCases := []struct {
+		sku         string
+		strict      bool
+		shouldError bool
+	}{
+		{"", true, false},
+		{"Free", false, false},
+		{"Free", true, true},
+		{"free", true, true},
+		{"Standard", true, false},
+		{"P20Premium", true, false},
+	}
+
+	for _, test := range testCases {
+		err := checkSchedulerJobCollectionFreeSkuQuota(test.sku, test.strict)
+
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected a quota for SKU %q (strict: %t) to fail", test.sku, test.strict)
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected a quota for SKU %q (strict: %t) not to fail: %+v", test.sku, test.strict, err)
+		}
+	}
+}
+
+func TestValidateSchedulerJobCollectionMaxJobCount(t *testing.T) {
+	testCases := []struct {
+		sku         string
+		maxJobCount int
+		shouldError bool
+	}{
+		{"", 5000, false},
+		{"Free", 0, false},
+		{"Free", 5, false},
+		{"free", 6, true},
+		{"Standard", 50, false},
+		{"Standard", 51, true},
+		{"P10Premium", 50, false},
+		{"P10Premium", 51, true},
+		{"P20Premium", 1000, false},
+		{"p20premium", 1001, true},
+	}
+
+	for _, test := range testCases {
+		err := validateSchedulerJobCollectionMaxJobCount(test.sku, test.maxJobCount)
+
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected validating a max job count of %d for SKU %q to fail", test.maxJobCount, test.sku)
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected validating a max job count of %d for SKU %q not to fail: %+v", test.maxJobCount, test.sku, err)
+		}
+	}
+}
+
+func TestSchedulerJobCollectionSkuTransitionSupported(t *testing.T) {
+	testCases := []struct {
+		old       string
+		new       string
+		supported bool
+	}{
+		{"Free", "Standard", true},
+		{"Free", "P10Premium", true},
+		{"Free", "P20Premium", true},
+		{"Standard", "P10Premium", true},
+		{"standard", "free", false},
+		{"P10Premium", "P20Premium", true},
+		{"P10Premium", "Standard", false},
+		{"P10Premium", "Free", false},
+		{"P20Premium", "P10Premium", true},
+		{"P20Premium", "standard", false},
+		{"p20premium", "Free", false},
+		{"P10Premium", "p10premium", true},
+		{"", "Standard", true},
+		{"P30Premium", "P20Premium", false},
+	}
+
+	for _, test := range testCases {
+		supported := schedulerJobCollectionSkuTransitionSupported(test.old, test.new)
+
+		if supported != test.supported {
+			t.Fatalf("Expected changing the SKU from %q to %q to be supported: %t but got %t", test.old, test.new, test.supported, supported)
+		}
+	}
+}
+
+func TestResourceArmSchedulerJobCollectionCustomizeDiff_skuTransitions(t *testing.T) {
+	free := string(scheduler.Free)
+	standard := string(scheduler.Standard)
+	p10 := string(scheduler.P10Premium)
+	p20 := string(scheduler.P20Premium)
+
+	// whether changing the SKU from the row's SKU to the column's SKU can be done in-place
+	skus := []string{free, standard, p10, p20}
+	inPlace := map[string][]bool{
+		free:     {true, true, true, true},
+		standard: {false, true, true, true},
+		p10:      {false, false, true, true},
+		p20:      {false, false, true, true},
+	}
+
+	r := resourceArmSchedulerJobCollection()
+
+	for _, old := range skus {
+		for i, new := range skus {
+			state := &terraform.InstanceState{
+				ID: schedulerJobCollectionID("00000000-0000-0000-0000-000000000000", "group1", "collection1"),
+				Attributes: map[string]string{
+					"name":                "collection1",
+					"location":            "westeurope",
+					"resource_group_name": "group1",
+					"sku":                 old,
+					"state":               string(scheduler.Enabled),
+				},
+			}
+
+			raw := map[string]interface{}{
+				"name":                "collection1",
+				"location":            "westeurope",
+				"resource_group_name": "group1",
+				"sku":                 new,
+			}
+
+			diff, err := r.Diff(state, terraform.NewResourceConfig(config.TestRawConfig(t, raw)), &ArmClient{})
+			if err != nil {
+				t.Fatalf("Expected no error changing the SKU from %q to %q but got: %+v", old, new, err)
+			}
+
+			if requiresNew := diff != nil && diff.RequiresNew(); requiresNew == inPlace[old][i] {
+				t.Fatalf("Expected changing the SKU from %q to %q to be in-place: %t but got a diff of %+v", old, new, inPlace[old][i], diff)
+			}
+		}
+	}
+}
+
+func TestValidateSchedulerJobCollectionCreation(t *testing.T) {
+	provider := `{"namespace": "Microsoft.Scheduler", "registrationState": "Registered", "resourceTypes": [{"resourceType": "jobCollections", "locations": ["West Europe", "North Europe"]}]}`
+
+	testCases := []struct {
+		name               string
+		collectionStatus   int
+		collectionResponse string
+		providerResponse   string
+		location           string
+		expectedError      string
+	}{
+		{
+			name:               "can be created",
+			collectionStatus:   http.StatusNotFound,
+			collectionResponse: `{"error": {"code": "ResourceNotFound", "message": "The Resource was not found."}}`,
+			providerResponse:   provider,
+			location:           "westeurope",
+		},
+		{
+			name:               "name in use",
+			collectionStatus:   http.StatusOK,
+			collectionResponse: `{"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1", "name": "collection1"}`,
+			providerResponse:   provider,
+			location:           "westeurope",
+			expectedError:      "already in use",
+		},
+		{
+			name:               "resource group not found",
+			collectionStatus:   http.StatusNotFound,
+			collectionResponse: `{"error": {"code": "ResourceGroupNotFound", "message": "Resource group 'group1' could not be found."}}`,
+			providerResponse:   provider,
+			location:           "westeurope",
+			expectedError:      "Resource Group \"group1\" was not found",
+		},
+		{
+			name:               "provider not registered",
+			collectionStatus:   http.StatusNotFound,
+			collectionResponse: `{"error": {"code": "ResourceNotFound", "message": "The Resource was not found."}}`,
+			providerResponse:   `{"namespace": "Microsoft.Scheduler", "registrationState": "NotRegistered"}`,
+			location:           "westeurope",
+			expectedError:      "isn't registered",
+		},
+		{
+			name:               "location unavailable",
+			collectionStatus:   http.StatusNotFound,
+			collectionResponse: `{"error": {"code": "ResourceNotFound", "message": "The Resource was not found."}}`,
+			providerResponse:   provider,
+			location:           "eastus2",
+			expectedError:      "isn't available in the region",
+		},
+	}
+
+	for _, test := range testCases {
+		respond := func(r *http.Request, statusCode int, body string) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: statusCode,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    r,
+			}, nil
+		}
+
+		created := false
+		client := scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Method != http.MethodGet {
+				created = true
+			}
+			return respond(r, test.collectionStatus, test.collectionResponse)
+		})
+
+		providersClient := resources.NewProvidersClient("00000000-0000-0000-0000-000000000000")
+		providersClient.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			return respond(r, http.StatusOK, test.providerResponse)
+		})
+
+		err := validateSchedulerJobCollectionCreation(context.Background(), client, providersClient, "group1", "collection1", test.location)
+
+		if created {
+			t.Fatalf("Expected validating %q not to create the Job Collection", test.name)
+		}
+
+		if test.expectedError == "" {
+			if err != nil {
+				t.Fatalf("Expected validating %q not to fail but got: %+v", test.name, err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+			t.Fatalf("Expected validating %q to fail with an error containing %q but got: %+v", test.name, test.expectedError, err)
+		}
+	}
+}
+
+func TestResourceArmSchedulerJobCollectionCustomizeDiff_validateOnly(t *testing.T) {
+	testCases := []struct {
+		validateOnly     bool
+		expectedRequests int
+		expectedError    bool
+	}{
+		{false, 0, false},
+		{true, 1, true},
+	}
+
+	r := resourceArmSchedulerJobCollection()
+
+	for _, test := range testCases {
+		requests := 0
+		client := &ArmClient{StopContext: context.Background()}
+		client.schedulerJobCollectionsClient = scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+		client.schedulerJobCollectionsClient.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			requests++
+
+			// the name's already in use, which should be surfaced during the plan
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1", "name": "collection1"}`)),
+				Request:    r,
+			}, nil
+		})
+
+		raw := map[string]interface{}{
+			"name":                "collection1",
+			"location":            "westeurope",
+			"resource_group_name": "group1",
+			"sku":                 string(scheduler.Standard),
+			"validate_only":       test.validateOnly,
+		}
+
+		_, err := r.Diff(nil, terraform.NewResourceConfig(config.TestRawConfig(t, raw)), client)
+		if test.expectedError && (err == nil || !strings.Contains(err.Error(), "already in use")) {
+			t.Fatalf("Expected the plan to fail since the name is in use when `validate_only` is %t but got: %+v", test.validateOnly, err)
+		}
+		if !test.expectedError && err != nil {
+			t.Fatalf("Expected no error when `validate_only` is %t but got: %+v", test.validateOnly, err)
+		}
+
+		if requests != test.expectedRequests {
+			t.Fatalf("Expected %d requests when `validate_only` is %t but got %d", test.expectedRequests, test.validateOnly, requests)
+		}
+	}
+}
+
+func TestResourceArmSchedulerJobCollectionCreate_validateOnly(t *testing.T) {
+	respond := func(r *http.Request, statusCode int, body string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}
+
+	created := false
+	client := &ArmClient{StopContext: context.Background()}
+	client.schedulerJobCollectionsClient = scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+	client.schedulerJobCollectionsClient.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method != http.MethodGet {
+			created = true
+		}
+		return respond(r, http.StatusNotFound, `{"error": {"code": "ResourceNotFound", "message": "The Resource was not found."}}`)
+	})
+	client.providersClient = resources.NewProvidersClient("00000000-0000-0000-0000-000000000000")
+	client.providersClient.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		return respond(r, http.StatusOK, `{"namespace": "Microsoft.Scheduler", "registrationState": "Registered", "resourceTypes": [{"resourceType": "jobCollections", "locations": ["West Europe"]}]}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceArmSchedulerJobCollection().Schema, map[string]interface{}{
+		"name":                "collection1",
+		"location":            "westeurope",
+		"resource_group_name": "group1",
+		"sku":                 string(scheduler.Standard),
+		"validate_only":       true,
+	})
+
+	// the creation can be validated, so the apply should succeed - without creating the Job Collection or storing it
+	if err := resourceArmSchedulerJobCollectionCreateUpdate(d, client); err != nil {
+		t.Fatalf("Expected validating the creation not to fail but got: %+v", err)
+	}
+
+	if created {
+		t.Fatalf("Expected the Job Collection not to be created when `validate_only` is enabled")
+	}
+
+	if d.Id() != "" {
+		t.Fatalf("Expected no ID to be set when `validate_only` is enabled but got %q", d.Id())
+	}
+}
+
+func TestSchedulerJobCollectionID(t *testing.T) {
+	id := schedulerJobCollectionID("00000000-0000-0000-0000-000000000000", "group1", "collection1")
+	expected := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1"
+
+	if id != expected {
+		t.Fatalf("Expected %q but got %q", expected, id)
+	}
+
+	parsed, err := parseAzureResourceID(id)
+	if err != nil {
+		t.Fatalf("Expected %q to parse: %+v", id, err)
+	}
+
+	if parsed.ResourceGroup != "group1" {
+		t.Fatalf("Expected Resource Group to be %q but got %q", "group1", parsed.ResourceGroup)
+	}
+
+	if name := parsed.Path["jobCollections"]; name != "collection1" {
+		t.Fatalf("Expected Name to be %q but got %q", "collection1", name)
+	}
+}
+
+func TestExpandSchedulerJobCollectionImportID(t *testing.T) {
+	subscriptionId := "00000000-0000-0000-0000-000000000000"
+	expected := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1"
+
+	testCases := []struct {
+		input       string
+		expected    string
+		shouldError bool
+	}{
+		{expected, expected, false},
+		{"/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1", expected, false},
+		{"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/microsoft.scheduler/jobcollections/collection1", expected, false},
+		{expected + "/", expected, false},
+		{" " + expected + "\n", expected, false},
+		{"group1/collection1\n", expected, false},
+		{expected + "/jobs/job1", "", true},
+		{"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Logic/jobCollections/collection1", "", true},
+		{"group1/collection1", expected, false},
+		{"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1", "", true},
+		{"collection1", "", true},
+		{"group1/", "", true},
+		{"/collection1", "", true},
+		{"group1/collection1/extra", "", true},
+	}
+
+	for _, test := range testCases {
+		id, err := expandSchedulerJobCollectionImportID(test.input, subscriptionId)
+
+		if test.shouldError {
+			if err == nil {
+				t.Fatalf("Expected expanding %q to fail", test.input)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Expected expanding %q not to fail: %+v", test.input, err)
+		}
+
+		if id != test.expected {
+			t.Fatalf("Expected %q to expand to %q but got %q", test.input, test.expected, id)
+		}
+	}
+}
+
+func TestResourceArmSchedulerJobCollectionImport_defaults(t *testing.T) {
+	d := resourceArmSchedulerJobCollection().Data(nil)
+	d.SetId("group1/collection1")
+
+	meta := &ArmClient{
+		subscriptionId: "00000000-0000-0000-0000-000000000000",
+	}
+
+	results, err := resourceArmSchedulerJobCollectionImport(d, meta)
+	if err != nil {
+		t.Fatalf("Expected importing not to fail: %+v", err)
+	}
+
+	attributes := results[0].State().Attributes
+	for _, key := range []string{"ignore_external_state_changes", "force_delete", "include_job_count", "validate_only", "error_on_free_sku_quota"} {
+		if v, ok := attributes[key]; !ok || v != "false" {
+			t.Fatalf("Expected %q to be imported as %q but got %q", key, "false", v)
+		}
+	}
+}
+
+func TestResourceArmSchedulerJobCollectionPopulate_effectiveQuota(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceArmSchedulerJobCollection().Schema, map[string]interface{}{})
+
+	collection := scheduler.JobCollectionDefinition{
+		Name:     utils.String("collection1"),
+		Location: utils.String("westeurope"),
+		Properties: &scheduler.JobCollectionProperties{
+			Sku: &scheduler.Sku{
+				Name: scheduler.Free,
+			},
+			State: scheduler.Enabled,
+			Quota: &scheduler.JobCollectionQuota{
+				MaxJobCount: utils.Int32(5),
+				MaxRecurrence: &scheduler.JobMaxRecurrence{
+					Frequency: scheduler.Hour,
+					Interval:  utils.Int32(1),
+				},
+			},
+		},
+	}
+
+	if err := resourceArmSchedulerJobCollectionPopulate(d, "group1", &collection, false); err != nil {
+		t.Fatalf("Expected no error populating the Job Collection but got: %+v", err)
+	}
+
+	d.SetId(schedulerJobCollectionID("00000000-0000-0000-0000-000000000000", "group1", "collection1"))
+
+	expected := map[string]string{
+		"effective_quota.#":                          "1",
+		"effective_quota.0.max_job_count":            "5",
+		"effective_quota.0.max_recurrence_frequency": "Hour",
+		"effective_quota.0.max_retry_interval":       "1",
+	}
+	for k, v := range expected {
+		if actual := d.State().Attributes[k]; actual != v {
+			t.Fatalf("Expected %q to be %q but got %q", k, v, actual)
+		}
+	}
+}
+
+func TestResourceArmSchedulerJobCollectionPopulate_quotaFields(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   map[string]interface{}
+		Expected map[string]string
+	}{
+		{
+			Name:   "top-level fields",
+			Config: map[string]interface{}{},
+			Expected: map[string]string{
+				"quota.#":                  "",
+				"max_job_count":            "10",
+				"max_recurrence_frequency": "Minute",
+				"max_retry_interval":       "5",
+			},
+		},
+		{
+			Name: "deprecated quota block",
+			Config: map[string]interface{}{
+				"quota": []interface{}{
+					map[string]interface{}{
+						"max_job_count":            5,
+						"max_recurrence_frequency": "hour",
+						"max_retry_interval":       1,
+					},
+				},
+			},
+			Expected: map[string]string{
+				"quota.#":                          "1",
+				"quota.0.max_job_count":            "10",
+				"quota.0.max_recurrence_frequency": "Minute",
+				"quota.0.max_retry_interval":       "5",
+				"max_job_count":                    "0",
+				"max_recurrence_frequency":         "",
+				"max_retry_interval":               "0",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, resourceArmSchedulerJobCollection().Schema, tc.Config)
+
+		collection := scheduler.JobCollectionDefinition{
+			Name:     utils.String("collection1"),
+			Location: utils.String("westeurope"),
+			Properties: &scheduler.JobCollectionProperties{
+				Sku: &scheduler.Sku{
+					Name: scheduler.Standard,
+				},
+				State: scheduler.Enabled,
+				Quota: &scheduler.JobCollectionQuota{
+					MaxJobCount: utils.Int32(10),
+					MaxRecurrence: &scheduler.JobMaxRecurrence{
+						Frequency: scheduler.Minute,
+						Interval:  utils.Int32(5),
+					},
+				},
+			},
+		}
+
+		if err := resourceArmSchedulerJobCollectionPopulate(d, "group1", &collection, false); err != nil {
+			t.Fatalf("%s: Expected no error populating the Job Collection but got: %+v", tc.Name, err)
+		}
+
+		d.SetId(schedulerJobCollectionID("00000000-0000-0000-0000-000000000000", "group1", "collection1"))
+
+		for k, v := range tc.Expected {
+			if actual := d.State().Attributes[k]; actual != v {
+				t.Fatalf("%s: Expected %q to be %q but got %q", tc.Name, k, v, actual)
+			}
+		}
+	}
+}
+
+func TestExpandAzureArmSchedulerJobCollectionQuota_topLevelFields(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceArmSchedulerJobCollection().Schema, map[string]interface{}{
+		"max_job_count":            10,
+		"max_recurrence_frequency": "hour",
+		"max_retry_interval":       2,
+	})
+
+	quota := expandAzureArmSchedulerJobCollectionQuota(d)
+	if quota == nil {
+		t.Fatalf("Expected a quota but got nil")
+	}
+	if quota.MaxJobCount == nil || *quota.MaxJobCount != 10 {
+		t.Fatalf("Expected `MaxJobCount` to be 10 but got %+v", quota.MaxJobCount)
+	}
+	if quota.MaxRecurrence == nil {
+		t.Fatalf("Expected `MaxRecurrence` to be set but got nil")
+	}
+	if quota.MaxRecurrence.Frequency != scheduler.RecurrenceFrequency("hour") {
+		t.Fatalf("Expected `MaxRecurrence.Frequency` to be %q but got %q", "hour", quota.MaxRecurrence.Frequency)
+	}
+	if quota.MaxRecurrence.Interval == nil || *quota.MaxRecurrence.Interval != 2 {
+		t.Fatalf("Expected `MaxRecurrence.Interval` to be 2 but got %+v", quota.MaxRecurrence.Interval)
+	}
+
+	empty := schema.TestResourceDataRaw(t, resourceArmSchedulerJobCollection().Schema, map[string]interface{}{})
+	if quota := expandAzureArmSchedulerJobCollectionQuota(empty); quota != nil {
+		t.Fatalf("Expected no quota when none is specified but got %+v", quota)
+	}
+
+	// the API rejects a recurrence without a frequency
+	intervalOnly := schema.TestResourceDataRaw(t, resourceArmSchedulerJobCollection().Schema, map[string]interface{}{
+		"max_retry_interval": 2,
+	})
+	if quota := expandAzureArmSchedulerJobCollectionQuota(intervalOnly); quota == nil || quota.MaxRecurrence != nil {
+		t.Fatalf("Expected no `MaxRecurrence` when `max_recurrence_frequency` isn't specified but got %+v", quota)
+	}
+}
+
+func TestResourceArmSchedulerJobCollectionCustomizeDiff_maxRetryIntervalRequiresFrequency(t *testing.T) {
+	testCases := []struct {
+		raw         map[string]interface{}
+		shouldError bool
+	}{
+		{
+			raw:         map[string]interface{}{"max_retry_interval": 2},
+			shouldError: true,
+		},
+		{
+			raw:         map[string]interface{}{"max_retry_interval": 2, "max_recurrence_frequency": "hour"},
+			shouldError: false,
+		},
+		{
+			raw:         map[string]interface{}{"max_recurrence_frequency": "hour"},
+			shouldError: false,
+		},
+	}
+
+	r := resourceArmSchedulerJobCollection()
+
+	for _, test := range testCases {
+		raw := map[string]interface{}{
+			"name":                "collection1",
+			"location":            "westeurope",
+			"resource_group_name": "group1",
+			"sku":                 "standard",
+		}
+		for k, v := range test.raw {
+			raw[k] = v
+		}
+
+		_, err := r.Diff(nil, terraform.NewResourceConfig(config.TestRawConfig(t, raw)), &ArmClient{})
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected the plan to fail for %+v", test.raw)
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected the plan to succeed for %+v: %+v", test.raw, err)
+		}
+	}
+}
+
+func TestExpandAzureArmSchedulerJobCollectionQuota_quotaBlock(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceArmSchedulerJobCollection().Schema, map[string]interface{}{
+		"quota": []interface{}{
+			map[string]interface{}{
+				"max_job_count":            5,
+				"max_recurrence_frequency": "minute",
+			},
+		},
+	})
+
+	quota := expandAzureArmSchedulerJobCollectionQuota(d)
+	if quota == nil {
+		t.Fatalf("Expected a quota but got nil")
+	}
+	if quota.MaxJobCount == nil || *quota.MaxJobCount != 5 {
+		t.Fatalf("Expected `MaxJobCount` to be 5 but got %+v", quota.MaxJobCount)
+	}
+	if quota.MaxRecurrence == nil || quota.MaxRecurrence.Frequency != scheduler.RecurrenceFrequency("minute") {
+		t.Fatalf("Expected `MaxRecurrence.Frequency` to be %q but got %+v", "minute", quota.MaxRecurrence)
+	}
+
+	empty := schema.TestResourceDataRaw(t, resourceArmSchedulerJobCollection().Schema, map[string]interface{}{
+		"quota": []interface{}{
+			map[string]interface{}{},
+		},
+	})
+	if quota := expandAzureArmSchedulerJobCollectionQuota(empty); quota != nil {
+		t.Fatalf("Expected no quota for an empty `quota` block but got %+v", quota)
+	}
+}
+
+func TestResourceArmSchedulerJobCollectionUpdate_removeQuotaBlock(t *testing.T) {
+	r := resourceArmSchedulerJobCollection()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"quota": []interface{}{
+			map[string]interface{}{
+				"max_job_count":            5,
+				"max_recurrence_frequency": "hour",
+			},
+		},
+	})
+	collection := scheduler.JobCollectionDefinition{
+		Name:     utils.String("collection1"),
+		Location: utils.String("westeurope"),
+		Properties: &scheduler.JobCollectionProperties{
+			Sku: &scheduler.Sku{
+				Name: scheduler.Standard,
+			},
+			State: scheduler.Enabled,
+			Quota: &scheduler.JobCollectionQuota{
+				MaxJobCount: utils.Int32(5),
+				MaxRecurrence: &scheduler.JobMaxRecurrence{
+					Frequency: scheduler.Hour,
+				},
+			},
+		},
+	}
+	if err := resourceArmSchedulerJobCollectionPopulate(d, "group1", &collection, false); err != nil {
+		t.Fatalf("Expected no error populating the Job Collection but got: %+v", err)
+	}
+	d.SetId(schedulerJobCollectionID("00000000-0000-0000-0000-000000000000", "group1", "collection1"))
+	state := d.State()
+
+	raw := map[string]interface{}{
+		"name":                "collection1",
+		"location":            "westeurope",
+		"resource_group_name": "group1",
+		"sku":                 "standard",
+	}
+	diff, err := r.Diff(state, terraform.NewResourceConfig(config.TestRawConfig(t, raw)), &ArmClient{})
+	if err != nil {
+		t.Fatalf("Expected no error removing the `quota` block but got: %+v", err)
+	}
+
+	var canPatch bool
+	var quota *scheduler.JobCollectionQuota
+	r.Update = func(d *schema.ResourceData, meta interface{}) error {
+		canPatch = schedulerJobCollectionCanPatch(d)
+		quota = expandAzureArmSchedulerJobCollectionQuota(d)
+		return nil
+	}
+	if _, err := r.Apply(state, diff, nil); err != nil {
+		t.Fatalf("Expected no error applying the removal of the `quota` block but got: %+v", err)
+	}
+
+	if canPatch {
+		t.Fatalf("Expected removing the `quota` block to require a CreateOrUpdate rather than a Patch")
+	}
+
+	if quota != nil {
+		t.Fatalf("Expected no quota to be sent when the `quota` block is removed but got %+v", quota)
+	}
+}
+
+func TestSchedulerJobCollectionQuotaBlock(t *testing.T) {
+	testCases := []struct {
+		input    []interface{}
+		expected bool
+	}{
+		{
+			input:    []interface{}{},
+			expected: false,
+		},
+		{
+			input:    []interface{}{nil},
+			expected: false,
+		},
+		{
+			input: []interface{}{
+				map[string]interface{}{
+					"max_job_count":            0,
+					"max_recurrence_frequency": "",
+					"max_retry_interval":       0,
+				},
+			},
+			expected: false,
+		},
+		{
+			input: []interface{}{
+				map[string]interface{}{
+					"max_job_count":            0,
+					"max_recurrence_frequency": "",
+					"max_retry_interval":       5,
+				},
+			},
+			expected: true,
+		},
+		{
+			input: []interface{}{
+				map[string]interface{}{
+					"max_recurrence_frequency": "hour",
+				},
+			},
+			expected: true,
+		},
+	}
+
+	for _, test := range testCases {
+		if _, ok := schedulerJobCollectionQuotaBlock(test.input); ok != test.expected {
+			t.Fatalf("Expected the `quota` block %+v to be present %t but got %t", test.input, test.expected, ok)
+		}
+	}
+}
+
+func TestResourceArmSchedulerJobCollectionCustomizeDiff_quotaBlock(t *testing.T) {
+	testCases := []struct {
+		quota       map[string]interface{}
+		shouldError bool
+	}{
+		{
+			quota:       map[string]interface{}{},
+			shouldError: false,
+		},
+		{
+			quota: map[string]interface{}{
+				"max_job_count":            5,
+				"max_recurrence_frequency": "hour",
+			},
+			shouldError: false,
+		},
+		{
+			quota: map[string]interface{}{
+				"max_job_count": 5,
+			},
+			shouldError: true,
+		},
+	}
+
+	r := resourceArmSchedulerJobCollection()
+
+	for _, test := range testCases {
+		raw := map[string]interface{}{
+			"name":                "collection1",
+			"location":            "westeurope",
+			"resource_group_name": "group1",
+			"sku":                 "standard",
+			"quota":               []interface{}{test.quota},
+		}
+
+		_, err := r.Diff(nil, terraform.NewResourceConfig(config.TestRawConfig(t, raw)), &ArmClient{})
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected the plan to fail for the `quota` block %+v", test.quota)
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected the plan to succeed for the `quota` block %+v: %+v", test.quota, err)
+		}
+	}
+}
+
+func TestCountSchedulerJobs(t *testing.T) {
+	// the jobs are returned across two pages
+	pages := []string{
+		`{"value": [{"name": "job1"}, {"name": "job2"}], "nextLink": "https://management.azure.com/jobs?page=2"}`,
+		`{"value": [{"name": "job3"}]}`,
+	}
+
+	requests := 0
+	client := scheduler.NewJobsClient("00000000-0000-0000-0000-000000000000")
+	client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		body := pages[requests]
+		requests++
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+
+	count, err := countSchedulerJobs(context.Background(), client, "group1", "collection1")
+	if err != nil {
+		t.Fatalf("Expected no error counting the jobs but got: %+v", err)
+	}
+
+	if count != 3 {
+		t.Fatalf("Expected 3 jobs but got %d", count)
+	}
+
+	if requests != len(pages) {
+		t.Fatalf("Expected %d requests but got %d", len(pages), requests)
+	}
+}
+
+// testSchedulerJobsClient returns a Jobs Client backed by the `jobs` map (of Job name to state), which is updated when
+// a Job is patched - patching the Job named `failPatch` returns an error.
+func testSchedulerJobsClient(t *testing.T, jobs map[string]scheduler.JobState, failPatch string) (scheduler.JobsClient, *[]string) {
+	patched := make([]string, 0)
+
+	client := scheduler.NewJobsClient("00000000-0000-0000-0000-000000000000")
+	client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		respond := func(statusCode int, body string) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: statusCode,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    r,
+			}, nil
+		}
+
+		segments := strings.Split(r.URL.Path, "/")
+		if segments[len(segments)-1] == "jobs" {
+			values := make([]string, 0)
+			for name, state := range jobs {
+				values = append(values, fmt.Sprintf(`{"name": "collection1/%s", "properties": {"state": %q}}`, name, state))
+			}
+			return respond(http.StatusOK, fmt.Sprintf(`{"value": [%s]}`, strings.Join(values, ",")))
+		}
+
+		name := segments[len(segments)-1]
+		state, exists := jobs[name]
+		if !exists {
+			return respond(http.StatusNotFound, `{"error": {"code": "ResourceNotFound"}}`)
+		}
+
+		if r.Method == http.MethodPatch {
+			if name == failPatch {
+				return respond(http.StatusBadRequest, `{"error": {"code": "BadRequest"}}`)
+			}
+
+			var job scheduler.JobDefinition
+			if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+				t.Fatalf("Error decoding the Patch request for Job %q: %+v", name, err)
+			}
+			if job.Properties == nil {
+				t.Fatalf("Expected the Patch request for Job %q to contain properties", name)
+			}
+
+			state = job.Properties.State
+			jobs[name] = state
+			patched = append(patched, name)
+		}
+
+		return respond(http.StatusOK, fmt.Sprintf(`{"name": "collection1/%s", "properties": {"state": %q}}`, name, state))
+	})
+
+	return client, &patched
+}
+
+func TestSuspendSchedulerJobs(t *testing.T) {
+	jobs := map[string]scheduler.JobState{
+		"enabled1":  scheduler.JobStateEnabled,
+		"enabled2":  scheduler.JobStateEnabled,
+		"disabled":  scheduler.JobStateDisabled,
+		"faulted":   scheduler.JobStateFaulted,
+		"completed": scheduler.JobStateCompleted,
+	}
+	client, patched := testSchedulerJobsClient(t, jobs, "")
+
+	disabled, err := suspendSchedulerJobs(context.Background(), client, "group1", "collection1")
+	if err != nil {
+		t.Fatalf("Expected no error suspending the jobs but got: %+v", err)
+	}
+
+	// only the jobs which were enabled should be tracked, so the others aren't re-enabled
+	sort.Strings(disabled)
+	if !reflect.DeepEqual(disabled, []string{"enabled1", "enabled2"}) {
+		t.Fatalf("Expected the enabled jobs to be disabled but got %+v", disabled)
+	}
+
+	if len(*patched) != 2 {
+		t.Fatalf("Expected 2 jobs to be patched but got %+v", *patched)
+	}
+
+	for name, state := range jobs {
+		if state == scheduler.JobStateEnabled {
+			t.Fatalf("Expected job %q to no longer be enabled", name)
+		}
+	}
+
+	if jobs["faulted"] != scheduler.JobStateFaulted || jobs["completed"] != scheduler.JobStateCompleted {
+		t.Fatalf("Expected the faulted and completed jobs to be left as-is but got %+v", jobs)
+	}
+}
+
+func TestSuspendSchedulerJobs_partialFailure(t *testing.T) {
+	jobs := map[string]scheduler.JobState{
+		"enabled1": scheduler.JobStateEnabled,
+		"enabled2": scheduler.JobStateEnabled,
+	}
+	client, patched := testSchedulerJobsClient(t, jobs, "enabled2")
+
+	disabled, err := suspendSchedulerJobs(context.Background(), client, "group1", "collection1")
+	if err == nil {
+		t.Fatalf("Expected an error suspending the jobs but didn't get one")
+	}
+
+	// the job disabled before the error (if any, since the ordering isn't guaranteed) must still be tracked
+	if !reflect.DeepEqual(disabled, *patched) {
+		t.Fatalf("Expected the jobs which were disabled (%+v) to be returned but got %+v", *patched, disabled)
+	}
+
+	for _, name := range disabled {
+		if jobs[name] != scheduler.JobStateDisabled {
+			t.Fatalf("Expected job %q to be disabled", name)
+		}
+	}
+}
+
+func TestResumeSchedulerJobs(t *testing.T) {
+	jobs := map[string]scheduler.JobState{
+		"suspended": scheduler.JobStateDisabled,
+		// re-enabled outside of Terraform whilst suspended
+		"reenabled": scheduler.JobStateEnabled,
+		// disabled by the user, so not tracked as suspended
+		"disabled": scheduler.JobStateDisabled,
+	}
+	client, patched := testSchedulerJobsClient(t, jobs, "")
+
+	// `deleted` was suspended, but has since been deleted
+	remaining, err := resumeSchedulerJobs(context.Background(), client, "group1", "collection1", []string{"suspended", "reenabled", "deleted"})
+	if err != nil {
+		t.Fatalf("Expected no error resuming the jobs but got: %+v", err)
+	}
+
+	if len(remaining) != 0 {
+		t.Fatalf("Expected no jobs to remain suspended but got %+v", remaining)
+	}
+
+	if !reflect.DeepEqual(*patched, []string{"suspended"}) {
+		t.Fatalf("Expected only the suspended job to be patched but got %+v", *patched)
+	}
+
+	if jobs["suspended"] != scheduler.JobStateEnabled {
+		t.Fatalf("Expected the suspended job to be enabled but got %q", jobs["suspended"])
+	}
+
+	if jobs["disabled"] != scheduler.JobStateDisabled {
+		t.Fatalf("Expected the job disabled by the user to remain disabled but got %q", jobs["disabled"])
+	}
+}
+
+func TestResumeSchedulerJobs_partialFailure(t *testing.T) {
+	jobs := map[string]scheduler.JobState{
+		"suspended1": scheduler.JobStateDisabled,
+		"suspended2": scheduler.JobStateDisabled,
+		"suspended3": scheduler.JobStateDisabled,
+	}
+	client, _ := testSchedulerJobsClient(t, jobs, "suspended2")
+
+	remaining, err := resumeSchedulerJobs(context.Background(), client, "group1", "collection1", []string{"suspended1", "suspended2", "suspended3"})
+	if err == nil {
+		t.Fatalf("Expected an error resuming the jobs but didn't get one")
+	}
+
+	// the job which failed and those after it are still suspended, so re-enabling them is retried
+	if !reflect.DeepEqual(remaining, []string{"suspended2", "suspended3"}) {
+		t.Fatalf("Expected the jobs which weren't re-enabled to be returned but got %+v", remaining)
+	}
+
+	if jobs["suspended1"] != scheduler.JobStateEnabled {
+		t.Fatalf("Expected the first job to be enabled but got %q", jobs["suspended1"])
+	}
+}
+
+func TestSchedulerJobName(t *testing.T) {
+	testCases := []struct {
+		input    *string
+		expected string
+	}{
+		{
+			input:    nil,
+			expected: "",
+		},
+		{
+			input:    utils.String("job1"),
+			expected: "job1",
+		},
+		{
+			input:    utils.String("collection1/job1"),
+			expected: "job1",
+		},
+	}
+
+	for _, test := range testCases {
+		if actual := schedulerJobName(test.input); actual != test.expected {
+			t.Fatalf("Expected %q but got %q", test.expected, actual)
+		}
+	}
+}
+
+func TestFetchSchedulerJobCollection(t *testing.T) {
+	testCases := []struct {
+		statusCode            int
+		body                  string
+		shouldError           bool
+		expectedSku           scheduler.SkuDefinition
+		expectedRawProperties string
+	}{
+		{
+			// `zones` isn't modelled by the SDK, but should be exposed in the raw properties
+			statusCode:            http.StatusOK,
+			body:                  `{"name": "collection1", "properties": {"sku": {"name": "Standard"}, "state": "Enabled", "zones": ["1"]}}`,
+			expectedSku:           scheduler.Standard,
+			expectedRawProperties: `{"sku": {"name": "Standard"}, "state": "Enabled", "zones": ["1"]}`,
+		},
+		{
+			statusCode:  http.StatusNotFound,
+			body:        `{"error": {"code": "ResourceNotFound"}}`,
+			shouldError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		client := scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: test.statusCode,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+				Request:    r,
+			}, nil
+		})
+
+		result, err := fetchSchedulerJobCollection(context.Background(), client, "group1", "collection1", "")
+		if test.shouldError {
+			if err == nil {
+				t.Fatalf("Expected an error for status code %d but didn't get one", test.statusCode)
+			}
+			if !utils.ResponseWasNotFound(result.collection.Response) {
+				t.Fatalf("Expected the response to be returned for status code %d", test.statusCode)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Expected no error for status code %d but got: %+v", test.statusCode, err)
+		}
+
+		if result.collection.Properties == nil || result.collection.Properties.Sku == nil || result.collection.Properties.Sku.Name != test.expectedSku {
+			t.Fatalf("Expected the collection to be parsed with the SKU %q", test.expectedSku)
+		}
+
+		if result.rawProperties != test.expectedRawProperties {
+			t.Fatalf("Expected the raw properties to be %q but got %q", test.expectedRawProperties, result.rawProperties)
+		}
+	}
+}
+
+func TestFetchSchedulerJobCollection_conditional(t *testing.T) {
+	testCases := []struct {
+		name                string
+		etag                string
+		supportsConditional bool
+		expectedIfNoneMatch string
+		expectedNotModified bool
+	}{
+		{
+			name:                "no etag",
+			etag:                "",
+			supportsConditional: true,
+			expectedIfNoneMatch: "",
+			expectedNotModified: false,
+		},
+		{
+			name:                "unchanged",
+			etag:                "abc123",
+			supportsConditional: true,
+			expectedIfNoneMatch: "abc123",
+			expectedNotModified: true,
+		},
+		{
+			name:                "changed",
+			etag:                "def456",
+			supportsConditional: true,
+			expectedIfNoneMatch: "def456",
+			expectedNotModified: false,
+		},
+		{
+			name:                "conditional requests unsupported",
+			etag:                "abc123",
+			supportsConditional: false,
+			expectedIfNoneMatch: "abc123",
+			expectedNotModified: false,
+		},
+	}
+
+	for _, test := range testCases {
+		ifNoneMatch := ""
+		client := scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			ifNoneMatch = r.Header.Get("If-None-Match")
+			if test.supportsConditional && ifNoneMatch == "abc123" {
+				return &http.Response{
+					StatusCode: http.StatusNotModified,
+					Body:       ioutil.NopCloser(strings.NewReader("")),
+					Request:    r,
+				}, nil
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}, "Etag": []string{"abc123"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"name": "collection1", "properties": {"sku": {"name": "Standard"}, "state": "Enabled"}}`)),
+				Request:    r,
+			}, nil
+		})
+
+		result, err := fetchSchedulerJobCollection(context.Background(), client, "group1", "collection1", test.etag)
+		if err != nil {
+			t.Fatalf("%s: Expected no error but got: %+v", test.name, err)
+		}
+
+		if ifNoneMatch != test.expectedIfNoneMatch {
+			t.Fatalf("%s: Expected the If-None-Match header to be %q but got %q", test.name, test.expectedIfNoneMatch, ifNoneMatch)
+		}
+
+		if result.notModified != test.expectedNotModified {
+			t.Fatalf("%s: Expected `notModified` to be %t but got %t", test.name, test.expectedNotModified, result.notModified)
+		}
+
+		if !result.notModified && (result.collection.Properties == nil || result.collection.Properties.Sku == nil) {
+			t.Fatalf("%s: Expected the collection to be returned when it's been modified", test.name)
+		}
+	}
+}
+
+func TestCreateOrUpdateSchedulerJobCollection_etag(t *testing.T) {
+	testCases := []struct {
+		etag            string
+		statusCode      int
+		shouldError     bool
+		expectedIfMatch string
+	}{
+		{"", http.StatusOK, false, ""},
+		{"abc123", http.StatusOK, false, "abc123"},
+		{"abc123", http.StatusPreconditionFailed, true, "abc123"},
+	}
+
+	for _, test := range testCases {
+		ifMatch := ""
+		client := scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			ifMatch = r.Header.Get("If-Match")
+			return &http.Response{
+				StatusCode: test.statusCode,
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+				Request:    r,
+			}, nil
+		})
+
+		collection, err := createOrUpdateSchedulerJobCollection(context.Background(), client, "group1", "collection1", scheduler.JobCollectionDefinition{}, test.etag, "")
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected an error for status code %d but didn't get one", test.statusCode)
+		}
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected no error for status code %d but got: %+v", test.statusCode, err)
+		}
+
+		if ifMatch != test.expectedIfMatch {
+			t.Fatalf("Expected the If-Match header to be %q but got %q", test.expectedIfMatch, ifMatch)
+		}
+
+		if test.statusCode == http.StatusPreconditionFailed && !response.WasPreconditionFailed(collection.Response.Response) {
+			t.Fatalf("Expected the response to be returned for a failed precondition")
+		}
+	}
+}
+
+func TestCreateOrUpdateSchedulerJobCollection_additionalProperties(t *testing.T) {
+	body := ""
+	client := scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+	client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Body != nil {
+			b, _ := ioutil.ReadAll(r.Body)
+			body = string(b)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			Request:    r,
+		}, nil
+	})
+
+	collection := scheduler.JobCollectionDefinition{
+		Location: utils.String("westeurope"),
+		Properties: &scheduler.JobCollectionProperties{
+			Sku: &scheduler.Sku{
+				Name: scheduler.Standard,
+			},
+			State: scheduler.Enabled,
+		},
+	}
+	additional := `{"state": "Disabled", "sku": {"name": "Free", "family": "example"}, "newProperty": true}`
+
+	if _, err := createOrUpdateSchedulerJobCollection(context.Background(), client, "group1", "collection1", collection, "", additional); err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	var sent struct {
+		Location   string                 `json:"location"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(body), &sent); err != nil {
+		t.Fatalf("Expected the request body to be JSON but got %q: %+v", body, err)
+	}
+
+	if sent.Location != "westeurope" {
+		t.Fatalf("Expected the `location` to be sent but got %q", body)
+	}
+
+	expected := map[string]interface{}{
+		"state": "Enabled",
+		"sku": map[string]interface{}{
+			"name":   "Standard",
+			"family": "example",
+		},
+		"newProperty": true,
+	}
+	if !reflect.DeepEqual(expected, sent.Properties) {
+		t.Fatalf("Expected the properties %+v but got %+v", expected, sent.Properties)
+	}
+}
+
+func TestValidateSchedulerJobCollectionAdditionalPropertiesJSON(t *testing.T) {
+	cases := []struct {
+		Value  string
+		Errors int
+	}{
+		{Value: `{}`, Errors: 0},
+		{Value: `{"newProperty": {"enabled": true}}`, Errors: 0},
+		{Value: `[]`, Errors: 1},
+		{Value: `"hello"`, Errors: 1},
+		{Value: `{"newProperty": `, Errors: 1},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateSchedulerJobCollectionAdditionalPropertiesJSON(tc.Value, "additional_properties_json")
+		if len(errors) != tc.Errors {
+			t.Fatalf("Expected %d errors for %q but got %d: %+v", tc.Errors, tc.Value, len(errors), errors)
+		}
+	}
+}
+
+func TestPatchSchedulerJobCollection(t *testing.T) {
+	testCases := []struct {
+		etag            string
+		statusCode      int
+		shouldError     bool
+		expectedIfMatch string
+	}{
+		{"", http.StatusOK, false, ""},
+		{"abc123", http.StatusOK, false, "abc123"},
+		{"abc123", http.StatusPreconditionFailed, true, "abc123"},
+	}
+
+	for _, test := range testCases {
+		method := ""
+		ifMatch := ""
+		body := ""
+		client := scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			method = r.Method
+			ifMatch = r.Header.Get("If-Match")
+			if r.Body != nil {
+				b, _ := ioutil.ReadAll(r.Body)
+				body = string(b)
+			}
+
+			return &http.Response{
+				StatusCode: test.statusCode,
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+				Request:    r,
+			}, nil
+		})
+
+		patch := scheduler.JobCollectionDefinition{
+			Tags: &map[string]*string{
+				"environment": utils.String("test"),
+			},
+			Properties: &scheduler.JobCollectionProperties{},
+		}
+
+		_, err := patchSchedulerJobCollection(context.Background(), client, "group1", "collection1", patch, test.etag, "")
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected an error for status code %d but didn't get one", test.statusCode)
+		}
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected no error for status code %d but got: %+v", test.statusCode, err)
+		}
+
+		if method != http.MethodPatch {
+			t.Fatalf("Expected a %q request but got %q", http.MethodPatch, method)
+		}
+
+		if ifMatch != test.expectedIfMatch {
+			t.Fatalf("Expected the If-Match header to be %q but got %q", test.expectedIfMatch, ifMatch)
+		}
+
+		if strings.Contains(body, "sku") {
+			t.Fatalf("Expected the SKU not to be sent when patching but got %q", body)
+		}
+	}
+}
+
+func TestMergeSchedulerJobCollectionTags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		existing *map[string]*string
+		tags     map[string]interface{}
+		removed  []string
+		expected map[string]string
+	}{
+		{
+			name:     "no existing tags",
+			existing: nil,
+			tags:     map[string]interface{}{"environment": "test"},
+			expected: map[string]string{"environment": "test"},
+		},
+		{
+			name: "unmanaged tags are preserved",
+			existing: &map[string]*string{
+				"environment": utils.String("test"),
+				"owner":       utils.String("someone"),
+			},
+			tags:     map[string]interface{}{"environment": "production"},
+			expected: map[string]string{"environment": "production", "owner": "someone"},
+		},
+		{
+			name: "removed managed tags are deleted",
+			existing: &map[string]*string{
+				"environment": utils.String("test"),
+				"cost-center": utils.String("1234"),
+				"owner":       utils.String("someone"),
+			},
+			tags:     map[string]interface{}{"environment": "test"},
+			removed:  []string{"cost-center"},
+			expected: map[string]string{"environment": "test", "owner": "someone"},
+		},
+		{
+			name: "tag names are case-insensitive",
+			existing: &map[string]*string{
+				"Environment": utils.String("test"),
+				"COST-CENTER": utils.String("1234"),
+			},
+			tags:     map[string]interface{}{"environment": "production"},
+			removed:  []string{"cost-center"},
+			expected: map[string]string{"environment": "production"},
+		},
+	}
+
+	for _, test := range testCases {
+		actual := make(map[string]string)
+		for k, v := range *mergeSchedulerJobCollectionTags(test.existing, test.tags, test.removed) {
+			actual[k] = *v
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Fatalf("%s: Expected the tags %+v but got %+v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestFilterSchedulerJobCollectionManagedTags(t *testing.T) {
+	input := &map[string]*string{
+		"Environment": utils.String("test"),
+		"owner":       utils.String("someone"),
+	}
+
+	actual := make(map[string]string)
+	for k, v := range *filterSchedulerJobCollectionManagedTags(input, map[string]interface{}{"environment": "test"}) {
+		actual[k] = *v
+	}
+
+	expected := map[string]string{"Environment": "test"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected only the managed tags %+v but got %+v", expected, actual)
+	}
+
+	if output := filterSchedulerJobCollectionManagedTags(nil, map[string]interface{}{}); output != nil {
+		t.Fatalf("Expected no tags to be returned but got %+v", *output)
+	}
+}
+
+func TestUpdateSchedulerJobCollectionTags_concurrentModification(t *testing.T) {
+	// a tag is added outside of Terraform between reading the tags and patching them, so the first patch fails
+	existingTags := []string{
+		`{"environment": "test", "cost-center": "1234", "owner": "someone"}`,
+		`{"environment": "test", "cost-center": "1234", "owner": "someone", "department": "finance"}`,
+	}
+
+	gets := 0
+	patches := make([]string, 0)
+	ifMatches := make([]string, 0)
+	client := scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+	client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodGet {
+			body := fmt.Sprintf(`{"name": "collection1", "tags": %s}`, existingTags[gets])
+			gets++
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": []string{"application/json"},
+					"Etag":         []string{fmt.Sprintf("etag%d", gets)},
+				},
+				Body:    ioutil.NopCloser(strings.NewReader(body)),
+				Request: r,
+			}, nil
+		}
+
+		b, _ := ioutil.ReadAll(r.Body)
+		patches = append(patches, string(b))
+		ifMatches = append(ifMatches, r.Header.Get("If-Match"))
+
+		statusCode := http.StatusOK
+		if len(patches) == 1 {
+			statusCode = http.StatusPreconditionFailed
+		}
+
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"name": "collection1"}`)),
+			Request:    r,
+		}, nil
+	})
+
+	tags := map[string]interface{}{"environment": "production"}
+	if _, err := updateSchedulerJobCollectionTags(context.Background(), client, "group1", "collection1", tags, []string{"cost-center"}); err != nil {
+		t.Fatalf("Expected no error updating the tags but got: %+v", err)
+	}
+
+	if gets != 2 || len(patches) != 2 {
+		t.Fatalf("Expected the tags to be read and patched twice but got %d reads and %d patches", gets, len(patches))
+	}
+
+	if !reflect.DeepEqual(ifMatches, []string{"etag1", "etag2"}) {
+		t.Fatalf("Expected each patch to be sent with the ETag it was based on but got %+v", ifMatches)
+	}
+
+	var patch struct {
+		Tags       map[string]string `json:"tags"`
+		Properties interface{}       `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(patches[1]), &patch); err != nil {
+		t.Fatalf("Error parsing the patch %q: %+v", patches[1], err)
+	}
+
+	expected := map[string]string{"environment": "production", "owner": "someone", "department": "finance"}
+	if !reflect.DeepEqual(patch.Tags, expected) {
+		t.Fatalf("Expected the tags %+v to be patched but got %+v", expected, patch.Tags)
+	}
+
+	if patch.Properties != nil {
+		t.Fatalf("Expected only the tags to be patched but got the properties %+v", patch.Properties)
+	}
+}
+
+func TestUpdateSchedulerJobCollectionTags_concurrentModificationRetriesExhausted(t *testing.T) {
+	patches := 0
+	client := scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+	client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		statusCode := http.StatusOK
+		if r.Method == http.MethodPatch {
+			patches++
+			statusCode = http.StatusPreconditionFailed
+		}
+
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"name": "collection1", "tags": {}}`)),
+			Request:    r,
+		}, nil
+	})
+
+	collection, err := updateSchedulerJobCollectionTags(context.Background(), client, "group1", "collection1", map[string]interface{}{"environment": "test"}, nil)
+	if err == nil {
+		t.Fatalf("Expected an error when the tags are always modified concurrently but didn't get one")
+	}
+
+	if !response.WasPreconditionFailed(collection.Response.Response) {
+		t.Fatalf("Expected the Precondition Failed response to be returned")
+	}
+
+	if patches != schedulerJobCollectionTagsUpdateAttempts {
+		t.Fatalf("Expected %d attempts to patch the tags but got %d", schedulerJobCollectionTagsUpdateAttempts, patches)
+	}
+}
+
+func TestUpdateSchedulerJobCollectionTags_transientErrors(t *testing.T) {
+	testCases := []struct {
+		name            string
+		getStatusCode   int
+		patchStatusCode int
+		expectError     bool
+		expectedPatches int
+	}{
+		{
+			name:            "retrieving the tags fails temporarily",
+			getStatusCode:   http.StatusServiceUnavailable,
+			patchStatusCode: http.StatusOK,
+			expectError:     false,
+			expectedPatches: 1,
+		},
+		{
+			name:            "patching the tags fails temporarily",
+			getStatusCode:   http.StatusOK,
+			patchStatusCode: http.StatusBadGateway,
+			expectError:     false,
+			expectedPatches: 3,
+		},
+		{
+			name:            "patching the tags fails permanently",
+			getStatusCode:   http.StatusOK,
+			patchStatusCode: http.StatusForbidden,
+			expectError:     true,
+			expectedPatches: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		gets := 0
+		patches := 0
+		client := scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+		// the SDK retries a request which failed temporarily once, after which it's retried by updateSchedulerJobCollectionTags
+		client.RetryAttempts = 1
+		client.RetryDuration = 0
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			// only the first two requests of each kind fail
+			statusCode := http.StatusOK
+			if r.Method == http.MethodPatch {
+				patches++
+				if patches <= 2 {
+					statusCode = test.patchStatusCode
+				}
+			} else {
+				gets++
+				if gets <= 2 {
+					statusCode = test.getStatusCode
+				}
+			}
+
+			return &http.Response{
+				StatusCode: statusCode,
+				Header:     http.Header{"Content-Type": []string{"application/json"}, "Retry-After": []string{"0"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"name": "collection1", "tags": {}}`)),
+				Request:    r,
+			}, nil
+		})
+
+		_, err := updateSchedulerJobCollectionTags(context.Background(), client, "group1", "collection1", map[string]interface{}{"environment": "test"}, nil)
+		if test.expectError && err == nil {
+			t.Fatalf("Expected an error when %s but didn't get one", test.name)
+		}
+
+		if !test.expectError && err != nil {
+			t.Fatalf("Expected no error when %s but got: %+v", test.name, err)
+		}
+
+		if patches != test.expectedPatches {
+			t.Fatalf("Expected %d attempts to patch the tags when %s but got %d", test.expectedPatches, test.name, patches)
+		}
+	}
+}
+
+func TestDeleteSchedulerJobCollection_forceDelete(t *testing.T) {
+	testCases := []struct {
+		forceDelete      bool
+		expectedRequests int
+	}{
+		{true, 1},
+		{false, 2},
+	}
+
+	for _, test := range testCases {
+		requests := 0
+		client := scheduler.NewJobCollectionsClient("00000000-0000-0000-0000-000000000000")
+		client.PollingDelay = 0
+		client.Sender = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			requests++
+
+			// the deletion is accepted, then completes when it's next polled
+			statusCode := http.StatusOK
+			header := http.Header{}
+			if requests == 1 {
+				statusCode = http.StatusAccepted
+				header.Set("Location", "https://management.azure.com/operations/delete")
+			}
+
+			return &http.Response{
+				StatusCode: statusCode,
+				Header:     header,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+				Request:    r,
+			}, nil
+		})
+
+		if err := deleteSchedulerJobCollection(context.Background(), client, "group1", "collection1", test.forceDelete); err != nil {
+			t.Fatalf("Expected no error deleting with `force_delete` %t but got: %+v", test.forceDelete, err)
+		}
+
+		if requests != test.expectedRequests {
+			t.Fatalf("Expected %d requests when `force_delete` is %t but got %d", test.expectedRequests, test.forceDelete, requests)
+		}
+	}
+}
+
+func TestSchedulerJobCollectionDefinitionDoesNotManageJobs(t *testing.T) {
+	// CreateOrUpdate on a Job Collection must not remove the Jobs within it - which holds as long as the Jobs
+	// aren't part of the model sent to the API. Should the SDK add them, updates need to preserve existing Jobs.
+	testCases := []struct {
+		model    interface{}
+		expected []string
+	}{
+		{scheduler.JobCollectionDefinition{}, []string{"id", "type", "name", "location", "tags", "properties"}},
+		{scheduler.JobCollectionProperties{}, []string{"sku", "state", "quota"}},
+	}
+
+	for _, test := range testCases {
+		modelType := reflect.TypeOf(test.model)
+
+		fields := make([]string, 0)
+		for i := 0; i < modelType.NumField(); i++ {
+			tag := modelType.Field(i).Tag.Get("json")
+			name := strings.Split(tag, ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+
+			fields = append(fields, name)
+		}
+
+		if !reflect.DeepEqual(fields, test.expected) {
+			t.Fatalf("The fields of %s have changed from %v to %v - ensure that CreateOrUpdate doesn't remove existing Jobs from the Job Collection before updating this test", modelType.Name(), test.expected, fields)
+		}
+	}
+}
+
+func TestAccAzureRMSchedulerJobCollection_basic(t *testing.T) {
+	ri := acctest.RandInt()
+	resourceName := "azurerm_scheduler_job_collection.test"
+	config := testAccAzureRMSchedulerJobCollection_basic(ri, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  checkAccAzureRMSchedulerJobCollection_basic(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMSchedulerJobCollection_validateOnly(t *testing.T) {
+	ri := acctest.RandInt()
+	config := testAccAzureRMSchedulerJobCollection_template(ri, testLocation(), "  validate_only = true")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckAzureRMSchedulerJobCollectionDestroy,
+			testCheckAzureRMSchedulerJobCollectionNotCreated("azurerm_scheduler_job_collection.test", fmt.Sprintf("acctestRG-%d", ri), fmt.Sprintf("acctest-%d", ri)),
+		),
+		Steps: []resource.TestStep{
+			{
+				// the apply succeeds once the creation's been validated, but since the Job Collection is never created
+				// nothing is stored in the state - and so it's planned for creation again
+				Config:             config,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestAccAzureRMSchedulerJobCollection_syntheticCassette replays the HTTP interactions in a cassette, so that the
+// create, update and delete of a Job Collection are covered without a real subscription. The cassette is a synthetic
+// fixture written by hand (rather than recorded against Azure), so this covers the Provider's requests and handling of
+// the responses - not that Azure's responses match.
+func TestAccAzureRMSchedulerJobCollection_syntheticCassette(t *testing.T) {
+	ri, location, restore := testAccUseCassette(t, "scheduler_job_collection_synthetic")
+	defer restore()
+
+	resourceName := "azurerm_scheduler_job_collection.test"
+	preConfig := testAccAzureRMSchedulerJobCollection_basic(ri, location)
+	config := testAccAzureRMSchedulerJobCollection_template(ri, location, `
+  tags {
+    environment = "acctest"
+  }
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: preConfig,
+				Check:  checkAccAzureRMSchedulerJobCollection_basic(resourceName),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSchedulerJobCollectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.environment", "acctest"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMSchedulerJobCollection_complete(t *testing.T) {
+	ri := acctest.RandInt()
+	resourceName := "azurerm_scheduler_job_collection.test"
+	preConfig := testAccAzureRMSchedulerJobCollection_basic(ri, testLocation())
+	config := testAccAzureRMSchedulerJobCollection_complete(ri, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: preConfig,
+				Check:  checkAccAzureRMSchedulerJobCollection_basic(resourceName),
+			},
+			{
+				Config: config,
+				Check:  checkAccAzureRMSchedulerJobCollection_complete(resourceName),
+			},
+		},
+	})
+}
 
-	"github.com/hashicorp/terraform/helper/acctest"
-	"github.com/hashicorp/terraform/helper/resource"
-	"github.com/hashicorp/terraform/terraform"
-	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
-)
+func TestAccAzureRMSchedulerJobCollection_update(t *testing.T) {
+	ri := acctest.RandInt()
+	resourceName := "azurerm_scheduler_job_collection.test"
+	location := testLocation()
 
-func TestAccAzureRMSchedulerJobCollection_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMSchedulerJobCollection_basic(ri, location),
+				Check:  checkAccAzureRMSchedulerJobCollection_basic(resourceName),
+			},
+			{
+				Config: testAccAzureRMSchedulerJobCollection_complete(ri, location),
+				Check:  checkAccAzureRMSchedulerJobCollection_complete(resourceName),
+			},
+			{
+				Config: testAccAzureRMSchedulerJobCollection_update(ri, location),
+				Check:  checkAccAzureRMSchedulerJobCollection_update(resourceName),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMSchedulerJobCollection_unmanagedTags(t *testing.T) {
+	ri := acctest.RandInt()
+	resourceName := "azurerm_scheduler_job_collection.test"
+	location := testLocation()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMSchedulerJobCollection_template(ri, location, `
+  tags {
+    environment = "acctest"
+    cost-center = "1234"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSchedulerJobCollectionExists(resourceName),
+					testCheckAzureRMSchedulerJobCollectionAddTag(resourceName, "owner", "acctest"),
+				),
+			},
+			{
+				Config: testAccAzureRMSchedulerJobCollection_template(ri, location, `
+  tags {
+    environment = "production"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.environment", "production"),
+					testCheckAzureRMSchedulerJobCollectionTags(resourceName, map[string]string{
+						"environment": "production",
+						"owner":       "acctest",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMSchedulerJobCollection_quotaFields(t *testing.T) {
+	ri := acctest.RandInt()
+	resourceName := "azurerm_scheduler_job_collection.test"
+	location := testLocation()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMSchedulerJobCollection_quotaFields(ri, location, 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckAzureRMSchedulerJobCollectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "quota.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "max_job_count", "5"),
+					resource.TestCheckResourceAttr(resourceName, "max_retry_interval", "10"),
+					resource.TestCheckResourceAttr(resourceName, "max_recurrence_frequency", "Hour"),
+				),
+			},
+			{
+				Config: testAccAzureRMSchedulerJobCollection_quotaFields(ri, location, 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckAzureRMSchedulerJobCollectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "max_job_count", "10"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMSchedulerJobCollection_requiresImport(t *testing.T) {
+	ri := acctest.RandInt()
+	resourceName := "azurerm_scheduler_job_collection.test"
+	location := testLocation()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMSchedulerJobCollection_requiresImportTemplate(ri, location, false),
+				Check:  testCheckAzureRMSchedulerJobCollectionExists(resourceName),
+			},
+			{
+				Config:      testAccAzureRMSchedulerJobCollection_requiresImportTemplate(ri, location, true),
+				ExpectError: testRequiresImportError("azurerm_scheduler_job_collection"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMSchedulerJobCollection_jobCount(t *testing.T) {
 	ri := acctest.RandInt()
 	resourceName := "azurerm_scheduler_job_collection.test"
-	config := testAccAzureRMSchedulerJobCollection_basic(ri, testLocation(), "")
+	location := testLocation()
+	jobName := fmt.Sprintf("acctestjob-%d", ri)
+	config := testAccAzureRMSchedulerJobCollection_template(ri, location, "  include_job_count = true")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -24,17 +2042,27 @@ func TestAccAzureRMSchedulerJobCollection_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: config,
-				Check:  checkAccAzureRMSchedulerJobCollection_basic(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSchedulerJobCollectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "job_count", "0"),
+					testCheckAzureRMSchedulerJobCollectionCreateJob(resourceName, jobName),
+				),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "job_count", "1"),
+				),
 			},
 		},
 	})
 }
 
-func TestAccAzureRMSchedulerJobCollection_complete(t *testing.T) {
+func TestAccAzureRMSchedulerJobCollection_preservesJobs(t *testing.T) {
 	ri := acctest.RandInt()
 	resourceName := "azurerm_scheduler_job_collection.test"
-	preConfig := testAccAzureRMSchedulerJobCollection_basic(ri, testLocation(), "")
-	config := testAccAzureRMSchedulerJobCollection_complete(ri, testLocation())
+	location := testLocation()
+	jobName := fmt.Sprintf("acctestjob-%d", ri)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -42,12 +2070,47 @@ func TestAccAzureRMSchedulerJobCollection_complete(t *testing.T) {
 		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: preConfig,
-				Check:  checkAccAzureRMSchedulerJobCollection_basic(resourceName),
+				Config: testAccAzureRMSchedulerJobCollection_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					checkAccAzureRMSchedulerJobCollection_basic(resourceName),
+					testCheckAzureRMSchedulerJobCollectionCreateJob(resourceName, jobName),
+				),
+			},
+			{
+				// changing the SKU requires a full CreateOrUpdate of the Job Collection
+				Config: testAccAzureRMSchedulerJobCollection_premium(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "sku", string(scheduler.P10Premium)),
+					testCheckAzureRMSchedulerJobCollectionJobExists(resourceName, jobName),
+				),
 			},
+		},
+	})
+}
+
+func TestAccAzureRMSchedulerJobCollection_ignoreExternalStateChanges(t *testing.T) {
+	ri := acctest.RandInt()
+	resourceName := "azurerm_scheduler_job_collection.test"
+	config := testAccAzureRMSchedulerJobCollection_template(ri, testLocation(), `
+  ignore_external_state_changes = true
+`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSchedulerJobCollectionDestroy,
+		Steps: []resource.TestStep{
 			{
 				Config: config,
-				Check:  checkAccAzureRMSchedulerJobCollection_complete(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					checkAccAzureRMSchedulerJobCollection_basic(resourceName),
+					testCheckAzureRMSchedulerJobCollectionDisable(resourceName),
+				),
+			},
+			{
+				// the collection has been disabled outside of Terraform, which shouldn't result in a diff
+				Config:   config,
+				PlanOnly: true,
 			},
 		},
 	})
@@ -81,6 +2144,29 @@ func testCheckAzureRMSchedulerJobCollectionDestroy(s *terraform.State) error {
 	return nil
 }
 
+// testCheckAzureRMSchedulerJobCollectionNotCreated checks the Job Collection is neither in the state nor in Azure
+func testCheckAzureRMSchedulerJobCollectionNotCreated(name, resourceGroup, collectionName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, ok := s.RootModule().Resources[name]; ok {
+			return fmt.Errorf("Expected %q not to be in the state", name)
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).schedulerJobCollectionsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, collectionName)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return nil
+			}
+
+			return fmt.Errorf("Bad: Get on schedulerJobCollectionsClient: %+v", err)
+		}
+
+		return fmt.Errorf("Expected Scheduler Job Collection %q (Resource Group %q) not to have been created", collectionName, resourceGroup)
+	}
+}
+
 func testCheckAzureRMSchedulerJobCollectionExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// Ensure we have enough information in state to look up in API
@@ -111,34 +2197,247 @@ func testCheckAzureRMSchedulerJobCollectionExists(name string) resource.TestChec
 	}
 }
 
-func testAccAzureRMSchedulerJobCollection_basic(rInt int, location string, additional string) string {
-	return fmt.Sprintf(` 
-resource "azurerm_resource_group" "test" { 
-  name     = "acctestRG-%d" 
-  location = "%s" 
-} 
- 
-resource "azurerm_scheduler_job_collection" "test" { 
-  name                = "acctest-%d" 
-  location            = "${azurerm_resource_group.test.location}" 
-  resource_group_name = "${azurerm_resource_group.test.name}" 
-  sku                 = "Standard" 
-%s 
-} 
+func testCheckAzureRMSchedulerJobCollectionDisable(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).schedulerJobCollectionsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		future, err := client.Disable(ctx, resourceGroup, name)
+		if err != nil {
+			return fmt.Errorf("Bad: Disable on schedulerJobCollectionsClient: %+v", err)
+		}
+
+		if err := future.WaitForCompletion(ctx, client.Client); err != nil {
+			return fmt.Errorf("Bad: waiting for Disable on schedulerJobCollectionsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+// testCheckAzureRMSchedulerJobCollectionAddTag adds a tag to the Job Collection outside of Terraform
+func testCheckAzureRMSchedulerJobCollectionAddTag(name, key, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).schedulerJobCollectionsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		if _, err := updateSchedulerJobCollectionTags(ctx, client, resourceGroup, name, map[string]interface{}{key: value}, nil); err != nil {
+			return fmt.Errorf("Bad: adding tag %q to Scheduler Job Collection %q (resource group: %q): %+v", key, name, resourceGroup, err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMSchedulerJobCollectionTags(name string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).schedulerJobCollectionsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on schedulerJobCollectionsClient: %+v", err)
+		}
+
+		actual := make(map[string]string)
+		if resp.Tags != nil {
+			for k, v := range *resp.Tags {
+				actual[k] = *v
+			}
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			return fmt.Errorf("Bad: expected Scheduler Job Collection %q (resource group: %q) to have the tags %+v but got %+v", name, resourceGroup, expected, actual)
+		}
+
+		return nil
+	}
+}
+
+// testAccAzureRMSchedulerJobCollection_template returns a Job Collection (and the Resource Group containing it)
+// with any `additional` fields included - which allows fixtures for new fields to be added easily
+func testAccAzureRMSchedulerJobCollection_template(rInt int, location string, additional string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_scheduler_job_collection" "test" {
+  name                = "acctest-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  sku                 = "Standard"
+%s
+}
 `, rInt, location, rInt, additional)
 }
 
+func testAccAzureRMSchedulerJobCollection_requiresImportTemplate(rInt int, location string, includeImport bool) string {
+	template := fmt.Sprintf(`
+provider "azurerm" {
+  requires_import = true
+}
+
+%s
+`, testAccAzureRMSchedulerJobCollection_basic(rInt, location))
+
+	if !includeImport {
+		return template
+	}
+
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_scheduler_job_collection" "import" {
+  name                = "${azurerm_scheduler_job_collection.test.name}"
+  location            = "${azurerm_scheduler_job_collection.test.location}"
+  resource_group_name = "${azurerm_scheduler_job_collection.test.resource_group_name}"
+  sku                 = "${azurerm_scheduler_job_collection.test.sku}"
+}
+`, template)
+}
+
+func testCheckAzureRMSchedulerJobCollectionCreateJob(name string, jobName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).schedulerJobsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		job := scheduler.JobDefinition{
+			Properties: &scheduler.JobProperties{
+				StartTime: &date.Time{Time: time.Now().Add(time.Hour)},
+				Action: &scheduler.JobAction{
+					Type: scheduler.HTTPS,
+					Request: &scheduler.HTTPRequest{
+						URI:    utils.String("https://www.example.com"),
+						Method: utils.String("GET"),
+					},
+				},
+			},
+		}
+
+		if _, err := client.CreateOrUpdate(ctx, resourceGroup, name, jobName, job); err != nil {
+			return fmt.Errorf("Bad: CreateOrUpdate on schedulerJobsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMSchedulerJobCollectionJobExists(name string, jobName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %q", name)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).schedulerJobsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, name, jobName)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Scheduler Job %q was removed from Job Collection %q (Resource Group %q)", jobName, name, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on schedulerJobsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testAccAzureRMSchedulerJobCollection_basic(rInt int, location string) string {
+	return testAccAzureRMSchedulerJobCollection_template(rInt, location, "")
+}
+
 func testAccAzureRMSchedulerJobCollection_complete(rInt int, location string) string {
-	return testAccAzureRMSchedulerJobCollection_basic(rInt, location, ` 
-  state = "disabled" 
-  quota { 
-    max_recurrence_frequency = "Hour" 
-    max_retry_interval       = 10 
-    max_job_count            = 10 
-  } 
+	return testAccAzureRMSchedulerJobCollection_template(rInt, location, `
+  state = "disabled"
+
+  quota {
+    max_recurrence_frequency = "Hour"
+    max_retry_interval       = 10
+    max_job_count            = 10
+  }
+`)
+}
+
+func testAccAzureRMSchedulerJobCollection_premium(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_scheduler_job_collection" "test" {
+  name                = "acctest-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  sku                 = "P10Premium"
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMSchedulerJobCollection_update(rInt int, location string) string {
+	return testAccAzureRMSchedulerJobCollection_template(rInt, location, `
+  state = "suspended"
+
+  quota {
+    max_recurrence_frequency = "Day"
+    max_retry_interval       = 5
+    max_job_count            = 20
+  }
+
+  tags {
+    environment = "acctest"
+  }
 `)
 }
 
+func testAccAzureRMSchedulerJobCollection_quotaFields(rInt int, location string, maxJobCount int) string {
+	return testAccAzureRMSchedulerJobCollection_template(rInt, location, fmt.Sprintf(`
+  max_job_count            = %d
+  max_recurrence_frequency = "Hour"
+  max_retry_interval       = 10
+`, maxJobCount))
+}
+
 func checkAccAzureRMSchedulerJobCollection_basic(resourceName string) resource.TestCheckFunc {
 	return resource.ComposeTestCheckFunc(
 		testCheckAzureRMSchedulerJobCollectionExists(resourceName),
@@ -163,3 +2462,15 @@ func checkAccAzureRMSchedulerJobCollection_complete(resourceName string) resourc
 		resource.TestCheckResourceAttr(resourceName, "quota.0.max_recurrence_frequency", "hour"),
 	)
 }
+
+func checkAccAzureRMSchedulerJobCollection_update(resourceName string) resource.TestCheckFunc {
+	return resource.ComposeAggregateTestCheckFunc(
+		testCheckAzureRMSchedulerJobCollectionExists(resourceName),
+		resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+		resource.TestCheckResourceAttr(resourceName, "tags.environment", "acctest"),
+		resource.TestCheckResourceAttr(resourceName, "state", string(scheduler.Suspended)),
+		resource.TestCheckResourceAttr(resourceName, "quota.0.max_job_count", "20"),
+		resource.TestCheckResourceAttr(resourceName, "quota.0.max_retry_interval", "5"),
+		resource.TestCheckResourceAttr(resourceName, "quota.0.max_recurrence_frequency", "day"),
+	)
+}
@@ -0,0 +1,63 @@
+package azurerm
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// resourceCache is a short-lived, in-memory cache of API responses keyed by Resource ID.
+// Since the provider process only lives for a single Terraform run this is scoped to that
+// run - entries also expire after the ttl and must be invalidated whenever the resource is modified.
+type resourceCache struct {
+	ttl     time.Duration
+	lock    sync.Mutex
+	entries map[string]resourceCacheEntry
+}
+
+type resourceCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+func newResourceCache(ttl time.Duration) *resourceCache {
+	return &resourceCache{
+		ttl:     ttl,
+		entries: make(map[string]resourceCacheEntry),
+	}
+}
+
+func (c *resourceCache) get(id string) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := strings.ToLower(id)
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+func (c *resourceCache) set(id string, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries[strings.ToLower(id)] = resourceCacheEntry{
+		value:   value,
+		expires: time.Now().Add(c.ttl),
+	}
+}
+
+func (c *resourceCache) invalidate(id string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.entries, strings.ToLower(id))
+}
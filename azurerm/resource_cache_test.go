@@ -0,0 +1,47 @@
+package azurerm
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResourceCache(t *testing.T) {
+	cache := newResourceCache(time.Minute)
+	id := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1"
+
+	if _, ok := cache.get(id); ok {
+		t.Fatalf("Expected an empty cache not to contain %q", id)
+	}
+
+	cache.set(id, "first")
+
+	v, ok := cache.get(id)
+	if !ok {
+		t.Fatalf("Expected the cache to contain %q", id)
+	}
+	if v.(string) != "first" {
+		t.Fatalf("Expected the cached value to be %q but got %q", "first", v)
+	}
+
+	// Resource ID's are case-insensitive
+	if _, ok := cache.get(strings.ToUpper(id)); !ok {
+		t.Fatalf("Expected the cache to contain %q regardless of casing", id)
+	}
+
+	cache.invalidate(id)
+	if _, ok := cache.get(id); ok {
+		t.Fatalf("Expected %q to have been invalidated", id)
+	}
+}
+
+func TestResourceCache_expired(t *testing.T) {
+	cache := newResourceCache(-time.Second)
+	id := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1"
+
+	cache.set(id, "value")
+
+	if _, ok := cache.get(id); ok {
+		t.Fatalf("Expected %q to have expired", id)
+	}
+}
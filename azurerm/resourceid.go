@@ -102,6 +102,33 @@ func parseAzureResourceID(id string) (*ResourceID, error) {
 	return idObj, nil
 }
 
+// resourceIDSegments are the canonical casing of the segments common to Resource IDs, which some APIs return in
+// a different casing (e.g. `resourcegroups` rather than `resourceGroups`)
+var resourceIDSegments = []string{"subscriptions", "resourceGroups", "providers"}
+
+// normalizeResourceID returns the Resource ID with the casing of the common segments (`subscriptions`,
+// `resourceGroups` and `providers`) normalized, so that it matches the IDs constructed elsewhere - the casing
+// of the values (e.g. the Resource Group name) is left as-is.
+func normalizeResourceID(id string) string {
+	if !strings.HasPrefix(id, "/") {
+		return id
+	}
+
+	segments := strings.Split(id, "/")
+
+	// the keys are the odd segments, since the ID starts with a `/`
+	for i := 1; i < len(segments); i += 2 {
+		for _, segment := range resourceIDSegments {
+			if strings.EqualFold(segments[i], segment) {
+				segments[i] = segment
+				break
+			}
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
 func composeAzureResourceID(idObj *ResourceID) (id string, err error) {
 	if idObj.SubscriptionID == "" || idObj.ResourceGroup == "" {
 		return "", fmt.Errorf("SubscriptionID and ResourceGroup cannot be empty")
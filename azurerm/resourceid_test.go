@@ -233,3 +233,43 @@ func TestComposeAzureResourceID(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeResourceID(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1",
+			expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1",
+		},
+		{
+			input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1",
+			expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1",
+		},
+		{
+			input:    "/Subscriptions/00000000-0000-0000-0000-000000000000/RESOURCEGROUPS/group1/Providers/Microsoft.Scheduler/jobCollections/collection1",
+			expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1",
+		},
+		{
+			// the values are left as-is, even when they match one of the segments
+			input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/Providers/providers/Microsoft.Scheduler/jobCollections/ResourceGroups",
+			expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/Providers/providers/Microsoft.Scheduler/jobCollections/ResourceGroups",
+		},
+		{
+			input:    "group1/collection1",
+			expected: "group1/collection1",
+		},
+		{
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		actual := normalizeResourceID(test.input)
+		if actual != test.expected {
+			t.Fatalf("Expected %q to be normalized to %q but got %q", test.input, test.expected, actual)
+		}
+	}
+}
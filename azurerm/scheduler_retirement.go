@@ -0,0 +1,88 @@
+package azurerm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/scheduler/mgmt/2016-03-01/scheduler"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// schedulerMigrationGuideURL documents how to migrate from Azure Scheduler to Azure Logic Apps
+const schedulerMigrationGuideURL = "https://docs.microsoft.com/en-us/azure/scheduler/migrate-from-scheduler-to-logic-apps"
+
+// schedulerRetirementWarning is returned as a warning during the plan for each of the Scheduler resources
+var schedulerRetirementWarning = fmt.Sprintf("Azure Scheduler is being retired and is no longer available in some regions - Azure Logic Apps should be used instead, see %s for how to migrate", schedulerMigrationGuideURL)
+
+// the error codes returned by Azure when the Scheduler Resource Provider isn't available in a region
+var schedulerServiceUnavailableErrorCodes = []string{
+	"LocationNotAvailableForResourceType",
+	"NoRegisteredProviderFound",
+}
+
+// the Scheduler SKUs which are being retired ahead of Azure Scheduler itself, mapped to the SKU which should be used
+// instead - these can still be used, but a warning is returned during the plan
+var schedulerDeprecatedSkus = map[string]string{
+	strings.ToLower(string(scheduler.P10Premium)): string(scheduler.P20Premium),
+}
+
+// validateSchedulerDeprecatedSku wraps the validation for the `sku` of a Scheduler resource to also return a warning
+// when the SKU is being retired, pointing to its replacement
+func validateSchedulerDeprecatedSku(validate schema.SchemaValidateFunc) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		if validate != nil {
+			ws, errors = validate(v, k)
+		}
+
+		sku, ok := v.(string)
+		if !ok {
+			return ws, errors
+		}
+
+		if replacement, deprecated := schedulerDeprecatedSkus[strings.ToLower(sku)]; deprecated {
+			ws = append(ws, fmt.Sprintf("The %q SKU specified for %q is deprecated and is being retired - %q should be used instead", sku, k, replacement))
+		}
+
+		return ws, errors
+	}
+}
+
+// validateSchedulerRetirement wraps the validation for a (required) field of a Scheduler resource to also return
+// a warning about the retirement of Azure Scheduler - since warnings can only be returned during validation
+func validateSchedulerRetirement(validate schema.SchemaValidateFunc) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		if validate != nil {
+			ws, errors = validate(v, k)
+		}
+
+		ws = append(ws, schedulerRetirementWarning)
+		return ws, errors
+	}
+}
+
+// schedulerServiceUnavailableError returns an error pointing to the migration path when `err` is because Azure
+// Scheduler isn't available in the region, or nil if this isn't the case
+func schedulerServiceUnavailableError(err error, location string) error {
+	serviceError := armServiceError(err)
+	if serviceError == nil {
+		return nil
+	}
+
+	unavailable := strings.Contains(strings.ToLower(serviceError.Message), "not available")
+	for _, code := range schedulerServiceUnavailableErrorCodes {
+		if strings.EqualFold(serviceError.Code, code) {
+			unavailable = true
+		}
+	}
+
+	if !unavailable {
+		return nil
+	}
+
+	where := "this region"
+	if location != "" {
+		where = fmt.Sprintf("the region %q", location)
+	}
+
+	return fmt.Errorf("Azure Scheduler isn't available in %s since it's being retired - Azure Logic Apps should be used instead, see %s for how to migrate: %s", where, schedulerMigrationGuideURL, formatARMError(err))
+}
@@ -0,0 +1,127 @@
+package azurerm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func TestValidateSchedulerRetirement(t *testing.T) {
+	validate := validateSchedulerRetirement(validation.NoZeroValues)
+
+	ws, errs := validate("collection1", "name")
+	if len(errs) != 0 {
+		t.Fatalf("Expected no errors but got: %+v", errs)
+	}
+	if len(ws) != 1 || ws[0] != schedulerRetirementWarning {
+		t.Fatalf("Expected the retirement warning but got: %+v", ws)
+	}
+
+	_, errs = validate("", "name")
+	if len(errs) != 1 {
+		t.Fatalf("Expected the wrapped validation to return an error but got: %+v", errs)
+	}
+
+	ws, errs = validateSchedulerRetirement(nil)("collection1", "name")
+	if len(errs) != 0 || len(ws) != 1 {
+		t.Fatalf("Expected only the retirement warning but got warnings %+v and errors %+v", ws, errs)
+	}
+}
+
+func TestValidateSchedulerDeprecatedSku(t *testing.T) {
+	validate := validateSchedulerDeprecatedSku(validation.StringInSlice([]string{"Free", "Standard", "P10Premium", "P20Premium"}, true))
+
+	testCases := []struct {
+		sku         string
+		warning     bool
+		shouldError bool
+	}{
+		{"Standard", false, false},
+		{"P20Premium", false, false},
+		{"P10Premium", true, false},
+		{"p10premium", true, false},
+		{"P30Premium", false, true},
+	}
+
+	for _, test := range testCases {
+		ws, errs := validate(test.sku, "sku")
+		if (len(errs) > 0) != test.shouldError {
+			t.Fatalf("Expected validating the SKU %q to error: %t but got: %+v", test.sku, test.shouldError, errs)
+		}
+
+		if (len(ws) > 0) != test.warning {
+			t.Fatalf("Expected a warning for the SKU %q: %t but got: %+v", test.sku, test.warning, ws)
+		}
+
+		if test.warning && !strings.Contains(ws[0], "P20Premium") {
+			t.Fatalf("Expected the warning for the SKU %q to point to its replacement but got: %q", test.sku, ws[0])
+		}
+	}
+}
+
+func TestSchedulerServiceUnavailableError(t *testing.T) {
+	requestError := func(code, message string) error {
+		return autorest.DetailedError{
+			Original: &azure.RequestError{
+				ServiceError: &azure.ServiceError{
+					Code:    code,
+					Message: message,
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		err         error
+		unavailable bool
+	}{
+		{
+			name:        "plain error",
+			err:         errors.New("boom"),
+			unavailable: false,
+		},
+		{
+			name:        "unrelated service error",
+			err:         requestError("Conflict", "The Job Collection is being modified."),
+			unavailable: false,
+		},
+		{
+			name:        "location not available",
+			err:         requestError("LocationNotAvailableForResourceType", "The provided location 'westus2' is not available for resource type 'Microsoft.Scheduler/jobCollections'."),
+			unavailable: true,
+		},
+		{
+			name:        "no registered provider",
+			err:         requestError("NoRegisteredProviderFound", "No registered resource provider found for location 'westus2'."),
+			unavailable: true,
+		},
+		{
+			name:        "service not available message",
+			err:         requestError("BadRequest", "The Scheduler service is not available in this region."),
+			unavailable: true,
+		},
+	}
+
+	for _, test := range testCases {
+		err := schedulerServiceUnavailableError(test.err, "westus2")
+		if !test.unavailable {
+			if err != nil {
+				t.Fatalf("Expected no error for the %s but got: %+v", test.name, err)
+			}
+			continue
+		}
+
+		if err == nil {
+			t.Fatalf("Expected an error for the %s but didn't get one", test.name)
+		}
+
+		if !strings.Contains(err.Error(), schedulerMigrationGuideURL) || !strings.Contains(err.Error(), `"westus2"`) {
+			t.Fatalf("Expected the error for the %s to point to the migration guide for %q but got: %+v", test.name, "westus2", err)
+		}
+	}
+}
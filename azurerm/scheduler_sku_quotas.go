@@ -0,0 +1,70 @@
+package azurerm
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/scheduler/mgmt/2016-03-01/scheduler"
+)
+
+// schedulerSkuQuota is the documented limits for a Scheduler Job Collection SKU
+type schedulerSkuQuota struct {
+	sku                            string
+	maxJobCount                    int
+	recurrenceFrequencies          []string
+	minRecurrenceIntervalInMinutes int
+}
+
+// schedulerSkuQuotas are the documented limits for each Scheduler Job Collection SKU:
+// https://docs.microsoft.com/en-us/azure/scheduler/scheduler-limits-defaults-errors
+var schedulerSkuQuotas = []schedulerSkuQuota{
+	{
+		sku:         string(scheduler.Free),
+		maxJobCount: 5,
+		recurrenceFrequencies: []string{
+			string(scheduler.Hour),
+			string(scheduler.Day),
+			string(scheduler.Week),
+			string(scheduler.Month),
+		},
+		minRecurrenceIntervalInMinutes: 60,
+	},
+	{
+		sku:                            string(scheduler.Standard),
+		maxJobCount:                    50,
+		recurrenceFrequencies:          schedulerAllRecurrenceFrequencies(),
+		minRecurrenceIntervalInMinutes: 1,
+	},
+	{
+		sku:                            string(scheduler.P10Premium),
+		maxJobCount:                    50,
+		recurrenceFrequencies:          schedulerAllRecurrenceFrequencies(),
+		minRecurrenceIntervalInMinutes: 1,
+	},
+	{
+		sku:                            string(scheduler.P20Premium),
+		maxJobCount:                    1000,
+		recurrenceFrequencies:          schedulerAllRecurrenceFrequencies(),
+		minRecurrenceIntervalInMinutes: 1,
+	},
+}
+
+func schedulerAllRecurrenceFrequencies() []string {
+	return []string{
+		string(scheduler.Minute),
+		string(scheduler.Hour),
+		string(scheduler.Day),
+		string(scheduler.Week),
+		string(scheduler.Month),
+	}
+}
+
+// schedulerSkuQuotaForSku returns the documented limits for the (case-insensitive) SKU, if it's known
+func schedulerSkuQuotaForSku(sku string) (schedulerSkuQuota, bool) {
+	for _, quota := range schedulerSkuQuotas {
+		if strings.EqualFold(quota.sku, sku) {
+			return quota, true
+		}
+	}
+
+	return schedulerSkuQuota{}, false
+}
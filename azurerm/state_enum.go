@@ -0,0 +1,36 @@
+package azurerm
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// stateEnumSchema returns the Schema for an optional field which accepts one of the
+// (case-insensitive) `states` - where omitting the field is equivalent to `defaultState`.
+func stateEnumSchema(defaultState string, states []string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Default:          defaultState,
+		DiffSuppressFunc: stateEnumDiffSuppressFunc(defaultState),
+		ValidateFunc:     validation.StringInSlice(states, true),
+	}
+}
+
+// stateEnumDiffSuppressFunc returns a DiffSuppressFunc which ignores changes in casing
+// and treats an empty value as being `defaultState`, such that a resource imported
+// without this field being returned from the API doesn't show a diff.
+func stateEnumDiffSuppressFunc(defaultState string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		if old == "" {
+			old = defaultState
+		}
+		if new == "" {
+			new = defaultState
+		}
+
+		return strings.EqualFold(old, new)
+	}
+}
@@ -0,0 +1,31 @@
+package azurerm
+
+import (
+	"testing"
+)
+
+func TestStateEnumDiffSuppressFunc(t *testing.T) {
+	testCases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{"", "", true},
+		{"", "Enabled", true},
+		{"Enabled", "", true},
+		{"enabled", "Enabled", true},
+		{"Enabled", "Enabled", true},
+		{"", "Disabled", false},
+		{"Disabled", "", false},
+		{"Disabled", "Suspended", false},
+		{"suspended", "Suspended", true},
+	}
+
+	suppressFunc := stateEnumDiffSuppressFunc("Enabled")
+
+	for _, test := range testCases {
+		if actual := suppressFunc("state", test.old, test.new, nil); actual != test.suppress {
+			t.Fatalf("Expected the diff from %q to %q to be suppressed: %t but got %t", test.old, test.new, test.suppress, actual)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package azurerm
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 )
@@ -56,6 +57,10 @@ func validateAzureRMTags(v interface{}, k string) (ws []string, es []error) {
 			es = append(es, fmt.Errorf("the maximum length for a tag key is 512 characters: %q is %d characters", k, len(k)))
 		}
 
+		if err := validateTagKeyCharacters(k); err != nil {
+			es = append(es, err)
+		}
+
 		value, err := tagValueToString(v)
 		if err != nil {
 			es = append(es, err)
@@ -67,6 +72,87 @@ func validateAzureRMTags(v interface{}, k string) (ws []string, es []error) {
 	return
 }
 
+// tagKeyDisallowedCharacters are the characters which Azure doesn't allow in a tag key
+const tagKeyDisallowedCharacters = `<>%&\?/`
+
+// validateTagKeyCharacters returns an error when the tag key contains a character which Azure doesn't allow.
+// Since Terraform re-runs validation during the apply, this also catches keys which are only known at apply time
+// (e.g. those interpolated from computed values) before any request is made - rather than relying on expandTags.
+func validateTagKeyCharacters(key string) error {
+	// system tags are added by Azure (e.g. `hidden-link:/subscriptions/...`) and can contain these characters
+	if isSystemTag(key) {
+		return nil
+	}
+
+	if i := strings.IndexAny(key, tagKeyDisallowedCharacters); i != -1 {
+		return fmt.Errorf("the tag key %q contains the character %q, which isn't allowed - tag keys can't contain any of `%s`", key, key[i], tagKeyDisallowedCharacters)
+	}
+
+	return nil
+}
+
+// requiredTagsCustomizeDiff wraps the CustomizeDiff of a resource which supports tags, so that the plan fails when any
+// of the `required_tags` configured in the Provider haven't been specified.
+// Terraform 0.11 doesn't expose whether a value is known (and reading an unknown map panics) - so the tags are only
+// checked here when the number of tags is known, which it isn't when they're interpolated from a computed value. Any
+// other tags (including none) are instead checked during the apply by requiredTagsCreateUpdate.
+func requiredTagsCustomizeDiff(customizeDiff schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(diff *schema.ResourceDiff, v interface{}) error {
+		if client, ok := v.(*ArmClient); ok && len(client.requiredTags) > 0 {
+			if _, known := diff.GetOk("tags.%"); known {
+				tags, _ := diff.Get("tags").(map[string]interface{})
+				if err := validateRequiredTags(tags, client.requiredTags); err != nil {
+					return err
+				}
+			}
+		}
+
+		if customizeDiff != nil {
+			return customizeDiff(diff, v)
+		}
+
+		return nil
+	}
+}
+
+// requiredTagsCreateUpdate wraps the Create or Update of a resource which supports tags, so that any of the
+// `required_tags` which haven't been specified are caught during the apply (once the tags are known) before any
+// request is made
+func requiredTagsCreateUpdate(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		if client, ok := meta.(*ArmClient); ok && len(client.requiredTags) > 0 {
+			tags, _ := d.Get("tags").(map[string]interface{})
+			if err := validateRequiredTags(tags, client.requiredTags); err != nil {
+				return err
+			}
+		}
+
+		return f(d, meta)
+	}
+}
+
+// validateRequiredTags returns an error listing any of the `required` tag keys which aren't present in `tagsMap` - since
+// tag keys are case-insensitive in Azure these are compared case-insensitively
+func validateRequiredTags(tagsMap map[string]interface{}, required []string) error {
+	keys := make(map[string]struct{}, len(tagsMap))
+	for k := range tagsMap {
+		keys[strings.ToLower(k)] = struct{}{}
+	}
+
+	missing := make([]string, 0)
+	for _, k := range required {
+		if _, ok := keys[strings.ToLower(k)]; !ok {
+			missing = append(missing, fmt.Sprintf("`%s`", k))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("the tags %s are required by the Provider's `required_tags` but haven't been specified", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
 func expandTags(tagsMap map[string]interface{}) *map[string]*string {
 	output := make(map[string]*string, len(tagsMap))
 
@@ -93,3 +179,35 @@ func flattenAndSetTags(d *schema.ResourceData, tagsMap *map[string]*string) {
 
 	d.Set("tags", output)
 }
+
+// flattenAndSetTagsIgnoringSystemTags sets the tags, omitting any system tags injected by Azure
+// when `ignoreSystemTags` is set, so that they don't show up as a diff.
+func flattenAndSetTagsIgnoringSystemTags(d *schema.ResourceData, tagsMap *map[string]*string, ignoreSystemTags bool) {
+	if ignoreSystemTags {
+		tagsMap = filterSystemTags(tagsMap)
+	}
+
+	flattenAndSetTags(d, tagsMap)
+}
+
+// filterSystemTags returns the tags without any system tags (e.g. `hidden-link:`) which are injected by Azure
+func filterSystemTags(tagsMap *map[string]*string) *map[string]*string {
+	if tagsMap == nil {
+		return nil
+	}
+
+	output := make(map[string]*string, len(*tagsMap))
+	for k, v := range *tagsMap {
+		if isSystemTag(k) {
+			continue
+		}
+
+		output[k] = v
+	}
+
+	return &output
+}
+
+func isSystemTag(key string) bool {
+	return strings.HasPrefix(strings.ToLower(key), "hidden-")
+}
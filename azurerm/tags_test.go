@@ -4,6 +4,11 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
 func TestValidateMaximumNumberOfARMTags(t *testing.T) {
@@ -68,6 +73,43 @@ func TestValidateARMTagMaxValueLength(t *testing.T) {
 	}
 }
 
+func TestValidateARMTagKeyCharacters(t *testing.T) {
+	for _, character := range []string{"<", ">", "%", "&", "\\", "?", "/"} {
+		key := fmt.Sprintf("environment%sname", character)
+		tagsMap := map[string]interface{}{
+			key: "value",
+		}
+
+		_, es := validateAzureRMTags(tagsMap, "tags")
+		if len(es) != 1 {
+			t.Fatalf("Expected one validation error for a key containing %q but got %d", character, len(es))
+		}
+
+		if !strings.Contains(es[0].Error(), fmt.Sprintf("%q", key)) {
+			t.Fatalf("Expected the validation error for %q to contain the key but got %q", character, es[0].Error())
+		}
+
+		if !strings.Contains(es[0].Error(), fmt.Sprintf("%q", character[0])) {
+			t.Fatalf("Expected the validation error for %q to contain the character but got %q", character, es[0].Error())
+		}
+	}
+}
+
+func TestValidateARMTagKeyCharacters_valid(t *testing.T) {
+	tagsMap := map[string]interface{}{
+		"environment":     "production",
+		"cost-center_1.2": "12345",
+		"with spaces":     "value",
+		"value-chars":     "<>%&\\?/",
+		"hidden-link:/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Web/sites/site1": "Resource",
+	}
+
+	_, es := validateAzureRMTags(tagsMap, "tags")
+	if len(es) != 0 {
+		t.Fatalf("Expected no validation errors but got: %+v", es)
+	}
+}
+
 func TestExpandARMTags(t *testing.T) {
 	testData := make(map[string]interface{})
 	testData["key1"] = "value1"
@@ -95,3 +137,232 @@ func TestExpandARMTags(t *testing.T) {
 		}
 	}
 }
+
+func TestFilterSystemTags(t *testing.T) {
+	tags := map[string]*string{
+		"environment": utils.String("production"),
+		"hidden-link:/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Web/sites/site1": utils.String("Resource"),
+		"Hidden-Related:/subscriptions/00000000-0000-0000-0000-000000000000":                                                         utils.String("empty"),
+		"not-hidden-": utils.String("value"),
+	}
+
+	filtered := filterSystemTags(&tags)
+
+	if len(*filtered) != 2 {
+		t.Fatalf("Expected 2 tags but got %d: %+v", len(*filtered), *filtered)
+	}
+
+	for _, k := range []string{"environment", "not-hidden-"} {
+		if _, ok := (*filtered)[k]; !ok {
+			t.Fatalf("Expected the tag %q to be retained", k)
+		}
+	}
+
+	if len(tags) != 4 {
+		t.Fatalf("Expected the original tags not to be modified")
+	}
+
+	if filterSystemTags(nil) != nil {
+		t.Fatalf("Expected no tags to be returned when there are none")
+	}
+}
+
+func TestFlattenAndSetTagsIgnoringSystemTags(t *testing.T) {
+	tags := map[string]*string{
+		"environment":        utils.String("production"),
+		"hidden-title":       utils.String("My Site"),
+		"hidden-link:/blah/": utils.String("Resource"),
+	}
+
+	cases := []struct {
+		IgnoreSystemTags bool
+		Expected         int
+	}{
+		{IgnoreSystemTags: false, Expected: 3},
+		{IgnoreSystemTags: true, Expected: 1},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"tags": tagsSchema()}, map[string]interface{}{})
+		flattenAndSetTagsIgnoringSystemTags(d, &tags, tc.IgnoreSystemTags)
+
+		actual := d.Get("tags").(map[string]interface{})
+		if len(actual) != tc.Expected {
+			t.Fatalf("Expected %d tags when ignoring system tags is %t but got %d: %+v", tc.Expected, tc.IgnoreSystemTags, len(actual), actual)
+		}
+
+		if actual["environment"] != "production" {
+			t.Fatalf("Expected the user tag to be set but got %+v", actual)
+		}
+	}
+}
+
+func TestValidateRequiredTags(t *testing.T) {
+	testCases := []struct {
+		tags        map[string]interface{}
+		required    []string
+		shouldError bool
+	}{
+		{
+			tags:     map[string]interface{}{},
+			required: []string{},
+		},
+		{
+			tags:     map[string]interface{}{"environment": "production", "costCenter": "1234"},
+			required: []string{"environment", "costCenter"},
+		},
+		{
+			// tag keys are case-insensitive
+			tags:     map[string]interface{}{"Environment": "production", "COSTCENTER": "1234"},
+			required: []string{"environment", "costCenter"},
+		},
+		{
+			tags:        map[string]interface{}{"environment": "production"},
+			required:    []string{"environment", "costCenter"},
+			shouldError: true,
+		},
+		{
+			tags:        map[string]interface{}{},
+			required:    []string{"environment"},
+			shouldError: true,
+		},
+		{
+			tags:        nil,
+			required:    []string{"environment"},
+			shouldError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		err := validateRequiredTags(test.tags, test.required)
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected tags %+v to fail the required tags %+v", test.tags, test.required)
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected tags %+v to pass the required tags %+v: %+v", test.tags, test.required, err)
+		}
+	}
+}
+
+func TestValidateRequiredTags_message(t *testing.T) {
+	err := validateRequiredTags(map[string]interface{}{"environment": "production"}, []string{"environment", "costCenter", "owner"})
+	if err == nil {
+		t.Fatalf("Expected an error for the missing tags")
+	}
+
+	if !strings.Contains(err.Error(), "`costCenter`, `owner`") || strings.Contains(err.Error(), "`environment`") {
+		t.Fatalf("Expected the error to list only the missing tags but got: %s", err)
+	}
+}
+
+func TestRequiredTagsCustomizeDiff(t *testing.T) {
+	testCases := []struct {
+		tags         interface{}
+		requiredTags []string
+		shouldError  bool
+	}{
+		{
+			tags:         map[string]interface{}{},
+			requiredTags: []string{},
+		},
+		{
+			tags:         map[string]interface{}{"environment": "production"},
+			requiredTags: []string{"environment"},
+		},
+		{
+			tags:         map[string]interface{}{"owner": "platform"},
+			requiredTags: []string{"environment"},
+			shouldError:  true,
+		},
+		{
+			// no tags are known during the plan, so this is checked during the apply
+			tags:         map[string]interface{}{},
+			requiredTags: []string{"environment"},
+		},
+		{
+			// the tags are interpolated from a computed value
+			tags:         config.UnknownVariableValue,
+			requiredTags: []string{"environment"},
+		},
+		{
+			tags:         map[string]interface{}{"environment": config.UnknownVariableValue},
+			requiredTags: []string{"environment"},
+		},
+	}
+
+	resource := Provider().(*schema.Provider).ResourcesMap["azurerm_resource_group"]
+
+	for _, test := range testCases {
+		raw := map[string]interface{}{
+			"name":     "group1",
+			"location": "westeurope",
+		}
+		if tags, ok := test.tags.(map[string]interface{}); !ok || len(tags) > 0 {
+			raw["tags"] = test.tags
+		}
+
+		client := &ArmClient{
+			requiredTags: test.requiredTags,
+		}
+
+		_, err := resource.Diff(nil, terraform.NewResourceConfig(config.TestRawConfig(t, raw)), client)
+		if test.shouldError && err == nil {
+			t.Fatalf("Expected the plan to fail for tags %+v with the required tags %+v", test.tags, test.requiredTags)
+		}
+
+		if !test.shouldError && err != nil {
+			t.Fatalf("Expected the plan to succeed for tags %+v with the required tags %+v: %+v", test.tags, test.requiredTags, err)
+		}
+	}
+}
+
+func TestRequiredTagsCreateUpdate(t *testing.T) {
+	testCases := []struct {
+		tags         map[string]interface{}
+		requiredTags []string
+		shouldError  bool
+	}{
+		{
+			tags:         map[string]interface{}{},
+			requiredTags: []string{},
+		},
+		{
+			tags:         map[string]interface{}{"Environment": "production"},
+			requiredTags: []string{"environment"},
+		},
+		{
+			tags:         map[string]interface{}{},
+			requiredTags: []string{"environment"},
+			shouldError:  true,
+		},
+	}
+
+	resource := resourceArmResourceGroup()
+
+	for _, test := range testCases {
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+			"name":     "group1",
+			"location": "westeurope",
+			"tags":     test.tags,
+		})
+
+		called := false
+		create := requiredTagsCreateUpdate(func(d *schema.ResourceData, meta interface{}) error {
+			called = true
+			return nil
+		})
+
+		err := create(d, &ArmClient{requiredTags: test.requiredTags})
+		if test.shouldError {
+			if err == nil || called {
+				t.Fatalf("Expected the apply to fail before any request for tags %+v with the required tags %+v", test.tags, test.requiredTags)
+			}
+			continue
+		}
+
+		if err != nil || !called {
+			t.Fatalf("Expected the apply to succeed for tags %+v with the required tags %+v: %+v", test.tags, test.requiredTags, err)
+		}
+	}
+}
@@ -10,6 +10,10 @@ func ResponseWasConflict(resp autorest.Response) bool {
 	return responseWasStatusCode(resp, http.StatusConflict)
 }
 
+func ResponseWasForbidden(resp autorest.Response) bool {
+	return responseWasStatusCode(resp, http.StatusForbidden)
+}
+
 func ResponseWasNotFound(resp autorest.Response) bool {
 	return responseWasStatusCode(resp, http.StatusNotFound)
 }
@@ -48,6 +48,13 @@ func validateUUID(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
+func validateAzureResourceID(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := parseAzureResourceID(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q is an invalid Resource ID: %+v", k, err))
+	}
+	return
+}
+
 func validateDBAccountName(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 
@@ -85,6 +92,24 @@ func validateStringLength(maxLength int) schema.SchemaValidateFunc {
 	}
 }
 
+// validateDuration validates that the value is a non-negative duration in the format supported by Go,
+// e.g. `30s` or `5m`
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q is an invalid duration: %+v", k, err))
+		return
+	}
+
+	if duration < 0 {
+		errors = append(errors, fmt.Errorf("%q must not be negative, got %s", k, value))
+	}
+
+	return
+}
+
 func validateIso8601Duration() schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (s []string, es []error) {
 		v, ok := i.(string)
@@ -90,6 +90,38 @@ func TestValidateIntInSlice(t *testing.T) {
 
 }
 
+func TestValidateAzureResourceID(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "nonsense",
+			ErrCount: 1,
+		},
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1",
+			ErrCount: 0,
+		},
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Scheduler/jobCollections/collection1",
+			ErrCount: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateAzureResourceID(tc.Value, "example")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected validateAzureResourceID to trigger '%d' errors for '%s' - got '%d'", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
 func TestDBAccountName_validation(t *testing.T) {
 	str := acctest.RandString(50)
 	cases := []struct {
@@ -171,3 +203,43 @@ func TestValidateIso8601Duration(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateDuration(t *testing.T) {
+	cases := []struct {
+		Value  string
+		Errors int
+	}{
+		{
+			Value:  "",
+			Errors: 1,
+		},
+		{
+			Value:  "30",
+			Errors: 1,
+		},
+		{
+			Value:  "-5m",
+			Errors: 1,
+		},
+		{
+			Value:  "0s",
+			Errors: 0,
+		},
+		{
+			Value:  "30s",
+			Errors: 0,
+		},
+		{
+			Value:  "1h30m",
+			Errors: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateDuration(tc.Value, "example")
+
+		if len(errors) != tc.Errors {
+			t.Fatalf("Expected validateDuration to trigger '%d' errors for '%s' - got '%d'", tc.Errors, tc.Value, len(errors))
+		}
+	}
+}